@@ -2,10 +2,12 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"os"
@@ -17,6 +19,15 @@ import (
 type Router interface {
 	Route(from []float32, to []float32) (uint32, [][]float32)
 	Matrix(sources [][]float32, targets [][]float32) [][]uint32
+	WriteMatrix(
+		ctx context.Context,
+		w io.Writer,
+		sources [][]float32,
+		targets [][]float32,
+		format routingkit.MatrixFormat,
+	) error
+	Isochrone(ctx context.Context, center routingkit.Point, limits []uint32) ([]routingkit.Feature, bool, error)
+	InboundIsochrone(ctx context.Context, center routingkit.Point, limits []uint32) ([]routingkit.Feature, bool, error)
 }
 
 type parameters struct {
@@ -25,6 +36,7 @@ type parameters struct {
 	mapFile string
 	measure string
 	mode    string
+	stream  string
 	width   float64
 	height  float64
 	length  float64
@@ -42,11 +54,21 @@ var measureEnum = struct {
 }
 
 var modeEnum = struct {
-	TUPLES string
-	MATRIX string
+	TUPLES    string
+	MATRIX    string
+	ISOCHRONE string
 }{
-	TUPLES: "tuples",
-	MATRIX: "matrix",
+	TUPLES:    "tuples",
+	MATRIX:    "matrix",
+	ISOCHRONE: "isochrone",
+}
+
+var streamEnum = struct {
+	NDJSON string
+	BINARY string
+}{
+	NDJSON: string(routingkit.MatrixNDJSON),
+	BINARY: string(routingkit.MatrixBinary),
 }
 
 var profileEnum = struct {
@@ -142,6 +164,20 @@ func main() {
 		for i, p := range input.Points {
 			targets[i] = []float32{p.Lon, p.Lat}
 		}
+		if params.stream != "" {
+			err = client.WriteMatrix(
+				context.Background(),
+				params.out,
+				sources,
+				targets,
+				routingkit.MatrixFormat(params.stream),
+			)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error writing output: %v", err)
+				os.Exit(1)
+			}
+			break
+		}
 		distances := client.Matrix(sources, targets)
 
 		output := outputMatrix{Matrix: distances}
@@ -150,6 +186,33 @@ func main() {
 			fmt.Fprintf(os.Stderr, "error writing output: %v", err)
 			os.Exit(1)
 		}
+	case modeEnum.ISOCHRONE:
+		input, err := readIsochrone(params.in)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading input: %v\n", err)
+			os.Exit(1)
+		}
+
+		center := routingkit.Point{Lon: input.Center.Lon, Lat: input.Center.Lat}
+		isochrone := client.Isochrone
+		if input.Inbound {
+			isochrone = client.InboundIsochrone
+		}
+		features, snapped, err := isochrone(context.Background(), center, input.Limits)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error computing isochrone: %v\n", err)
+			os.Exit(1)
+		}
+		if !snapped {
+			fmt.Fprintf(os.Stderr, "center cannot be snapped to the road network\n")
+			os.Exit(1)
+		}
+
+		err = writeIsochrone(params.out, routingkit.NewFeatureCollection(features))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error writing output: %v", err)
+			os.Exit(1)
+		}
 	default:
 		fmt.Fprintf(os.Stderr, "invalid option for mode "+params.mode+"\n")
 		os.Exit(1)
@@ -193,7 +256,13 @@ func parseFlags() (params parameters, err error) {
 		&params.mode,
 		"mode",
 		modeEnum.TUPLES,
-		"tuples|matrix",
+		"tuples|matrix|isochrone",
+	)
+	flag.StringVar(
+		&params.stream,
+		"stream",
+		"",
+		"ndjson|binary. in matrix mode, write the rows while computing them.",
 	)
 	flag.Float64Var(
 		&params.length,
@@ -248,6 +317,12 @@ func parseFlags() (params parameters, err error) {
 		return parameters{}, errors.New("invalid option for profile" + profile)
 	}
 
+	switch params.stream {
+	case "", streamEnum.NDJSON, streamEnum.BINARY:
+	default:
+		return parameters{}, errors.New("invalid option for stream " + params.stream)
+	}
+
 	if out == "" {
 		params.out = os.Stdout
 	} else {
@@ -310,6 +385,30 @@ func writeMatrix(file *os.File, output outputMatrix) (err error) {
 	return nil
 }
 
+func readIsochrone(file *os.File) (in inputIsochrone, err error) {
+	dat, err := ioutil.ReadAll(file)
+	if err != nil {
+		return in, err
+	}
+	err = json.Unmarshal(dat, &in)
+	if err != nil {
+		return in, err
+	}
+	return in, nil
+}
+
+func writeIsochrone(file *os.File, output routingkit.FeatureCollection) (err error) {
+	b, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(b)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 type inputTuples struct {
 	Tuples []pointTuple `json:"tuples"`
 }
@@ -340,3 +439,11 @@ type inputMatrix struct {
 type outputMatrix struct {
 	Matrix [][]uint32 `json:"matrix"`
 }
+
+type inputIsochrone struct {
+	Center position `json:"center"`
+	Limits []uint32 `json:"limits"`
+	// Inbound selects the areas from which the center can be reached instead
+	// of those reachable from it.
+	Inbound bool `json:"inbound"`
+}
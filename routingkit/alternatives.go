@@ -0,0 +1,120 @@
+package routingkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// Alternatives finds the shortest route between the two points and up to k - 1
+// alternative routes. It returns the cost and the waypoints of each route,
+// starting with the shortest one and ordered by cost. The alternatives are
+// limited as set by WithAlternativeLimits. If the target is not reachable, a
+// single route costing MaxDistance is returned.
+func (c client) Alternatives(from, to []float32, k int) ([]uint32, [][][]float32) {
+	costs, waypoints, _ := c.AlternativesContext(context.Background(), from, to, k)
+	return costs, waypoints
+}
+
+// AlternativesContext is like Alternatives, but gives up waiting for a free
+// query slot when ctx is done, in which case ctx.Err() is returned. It also
+// returns an error if a point is invalid or if k is less than 1.
+func (c client) AlternativesContext(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+	k int,
+) ([]uint32, [][][]float32, error) {
+	f, err := pointFromSlice(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := pointFromSlice(to)
+	if err != nil {
+		return nil, nil, err
+	}
+	routes, err := c.alternatives(ctx, f, t, k, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	costs := make([]uint32, len(routes))
+	waypoints := make([][][]float32, len(routes))
+	for i, r := range routes {
+		costs[i] = r.Cost
+		waypoints[i] = toSlices(r.Waypoints)
+	}
+	return costs, waypoints, nil
+}
+
+// FindAlternatives is like Alternatives, but takes and returns typed points and
+// describes each route by a RouteResult, including its segments. It returns
+// an error if a point is invalid, if k is less than 1 or if ctx is done before
+// a free query slot becomes available.
+func (c client) FindAlternatives(ctx context.Context, from Point, to Point, k int) ([]RouteResult, error) {
+	if err := validatePoints(from, to); err != nil {
+		return nil, err
+	}
+	return c.alternatives(ctx, from, to, k, true)
+}
+
+func (c client) alternatives(
+	ctx context.Context,
+	from Point,
+	to Point,
+	k int,
+	includeSegments bool,
+) ([]RouteResult, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("number of routes must be at least 1, got %v", k)
+	}
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(counter)
+	resp := c.client.Alternatives(
+		counter,
+		c.snapRadius,
+		from.Lon,
+		from.Lat,
+		to.Lon,
+		to.Lat,
+		uint(k),
+		c.maxStretch,
+		c.maxOverlap,
+		true,
+		includeSegments,
+	)
+	defer routingkit.DeleteQueryResponseVector(resp)
+	routes := make([]RouteResult, resp.Size())
+	for i := range routes {
+		routes[i] = routeResult(resp.Get(i))
+	}
+	return routes, nil
+}
+
+// Alternatives finds the fastest route between the two points and up to k - 1
+// alternative routes. It returns the travel time and the waypoints of each
+// route, starting with the fastest one and ordered by travel time.
+func (c TravelTimeClient) Alternatives(from, to []float32, k int) ([]uint32, [][][]float32) {
+	return c.client.Alternatives(from, to, k)
+}
+
+// AlternativesContext is like Alternatives, but gives up waiting for a free
+// query slot when ctx is done, in which case ctx.Err() is returned. It also
+// returns an error if a point is invalid or if k is less than 1.
+func (c TravelTimeClient) AlternativesContext(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+	k int,
+) ([]uint32, [][][]float32, error) {
+	return c.client.AlternativesContext(ctx, from, to, k)
+}
+
+// FindAlternatives is like Alternatives, but takes and returns typed points and
+// describes each route by a RouteResult, including its segments.
+func (c TravelTimeClient) FindAlternatives(ctx context.Context, from Point, to Point, k int) ([]RouteResult, error) {
+	return c.client.FindAlternatives(ctx, from, to, k)
+}
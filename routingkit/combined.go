@@ -0,0 +1,167 @@
+package routingkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// CombinedClient answers both distance and travel time queries. It loads the
+// road network of the map file only once and keeps one contraction hierarchy
+// per measure.
+type CombinedClient struct {
+	network    routingkit.RoadNetwork
+	distance   client
+	travelTime client
+}
+
+// NewCombinedClient initializes a CombinedClient using the provided .osm.pbf
+// file. The .ch files for both measures will be created if they do not already
+// exist. WithCHPath cannot be used with a combined client, as it needs two
+// contraction hierarchies, but WithCacheDir can. It is the caller's
+// responsibility to call Delete on the client when it is no longer needed.
+func NewCombinedClient(mapFile string, profile Profile, opts ...ClientOption) (CombinedClient, error) {
+	options, err := newClientOptions(opts)
+	if err != nil {
+		return CombinedClient{}, err
+	}
+	if options.chPath != "" {
+		return CombinedClient{}, fmt.Errorf("a contraction hierarchy path cannot be used with a combined client")
+	}
+	m, err := parseMap(mapFile, profile, options)
+	if err != nil {
+		return CombinedClient{}, err
+	}
+	distanceCH, err := m.chFile(false, options)
+	if err != nil {
+		return CombinedClient{}, err
+	}
+	travelTimeCH, err := m.chFile(true, options)
+	if err != nil {
+		return CombinedClient{}, err
+	}
+
+	network, err := m.load(options)
+	if err != nil {
+		return CombinedClient{}, err
+	}
+	if err := options.install(); err != nil {
+		routingkit.DeleteRoadNetwork(network)
+		return CombinedClient{}, err
+	}
+	distance, err := newNetworkClient(network, distanceCH, false, options)
+	if err != nil {
+		routingkit.DeleteRoadNetwork(network)
+		return CombinedClient{}, err
+	}
+	travelTime, err := newNetworkClient(network, travelTimeCH, true, options)
+	if err != nil {
+		routingkit.DeleteClient(distance.client)
+		routingkit.DeleteRoadNetwork(network)
+		return CombinedClient{}, err
+	}
+
+	return CombinedClient{
+		network:    network,
+		distance:   distance,
+		travelTime: travelTime,
+	}, nil
+}
+
+// Delete deletes the client, releasing memory allocated for C++ routing data
+// structures. Clients returned by DistanceClient and TravelTimeClient must not
+// be used afterwards. Calling Delete again has no effect.
+func (c *CombinedClient) Delete() {
+	if c.network == nil {
+		return
+	}
+	routingkit.DeleteClient(c.distance.client)
+	routingkit.DeleteClient(c.travelTime.client)
+	routingkit.DeleteRoadNetwork(c.network)
+	c.distance.client = nil
+	c.travelTime.client = nil
+	c.network = nil
+}
+
+// SetSnapRadius updates Client so that all queries will snap points to the nearest
+// street network point within the given radius in meters. It does not affect
+// clients previously returned by DistanceClient and TravelTimeClient.
+func (c *CombinedClient) SetSnapRadius(n float32) {
+	c.distance.SetSnapRadius(n)
+	c.travelTime.SetSnapRadius(n)
+}
+
+// DistanceClient returns a DistanceClient that shares the road network and
+// contraction hierarchy of the combined client. It is deleted together with
+// the combined client, so calling Delete on it has no effect.
+func (c CombinedClient) DistanceClient() DistanceClient {
+	shared := c.distance
+	shared.shared = true
+	return DistanceClient{client: shared}
+}
+
+// TravelTimeClient returns a TravelTimeClient that shares the road network and
+// contraction hierarchy of the combined client. It is deleted together with
+// the combined client, so calling Delete on it has no effect.
+func (c CombinedClient) TravelTimeClient() TravelTimeClient {
+	shared := c.travelTime
+	shared.shared = true
+	return TravelTimeClient{client: shared}
+}
+
+// RouteBoth finds the fastest route between the two points, returning the
+// total route distance in meters and travel time in milliseconds of that same
+// route, and the waypoints describing the route.
+func (c CombinedClient) RouteBoth(from []float32, to []float32) (uint32, uint32, [][]float32) {
+	distance, travelTime, waypoints, _ := c.RouteBothContext(context.Background(), from, to)
+	return distance, travelTime, waypoints
+}
+
+// RouteBothContext is like RouteBoth, but gives up waiting for a free query
+// slot when ctx is done, in which case ctx.Err() is returned. It also returns
+// an error if a point is invalid.
+func (c CombinedClient) RouteBothContext(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+) (uint32, uint32, [][]float32, error) {
+	result, err := c.travelTime.findRoute(ctx, from, to, true)
+	if err != nil {
+		return MaxDistance, MaxDistance, nil, err
+	}
+	return result.Distance, result.TravelTime, toSlices(result.Waypoints), nil
+}
+
+// FindRoute finds the fastest route between the two points. The distance and
+// travel time of the route are reported in the Distance and TravelTime fields
+// of the result. It returns an error if a point is invalid or if ctx is done
+// before a free query slot becomes available.
+func (c CombinedClient) FindRoute(ctx context.Context, from Point, to Point) (RouteResult, error) {
+	return c.travelTime.FindRoute(ctx, from, to)
+}
+
+// FindHeadedRoute is like FindRoute, but snaps points with a heading to roads
+// in their direction of travel.
+func (c CombinedClient) FindHeadedRoute(ctx context.Context, from HeadedPoint, to HeadedPoint) (RouteResult, error) {
+	return c.travelTime.FindHeadedRoute(ctx, from, to)
+}
+
+// MatrixBoth creates two matrices representing the distances and travel times
+// of the fastest routes from the points in sources to the points in targets.
+// The entries of both matrices describe the same routes.
+func (c CombinedClient) MatrixBoth(sources [][]float32, targets [][]float32) ([][]uint32, [][]uint32) {
+	distances, travelTimes, _ := c.MatrixBothContext(context.Background(), sources, targets)
+	return distances, travelTimes
+}
+
+// MatrixBothContext is like MatrixBoth, but stops computing new rows once ctx
+// is done. In that case the returned matrices are partial: rows that were not
+// computed are nil, and the returned error is ctx.Err().
+func (c CombinedClient) MatrixBothContext(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+) ([][]uint32, [][]uint32, error) {
+	return c.travelTime.matrixWithMetrics(ctx, sources, targets)
+}
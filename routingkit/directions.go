@@ -0,0 +1,281 @@
+package routingkit
+
+import (
+	"fmt"
+	"math"
+)
+
+// ManeuverType describes the kind of a maneuver.
+type ManeuverType string
+
+const (
+	// ManeuverDepart starts the route.
+	ManeuverDepart ManeuverType = "depart"
+	// ManeuverTurn turns onto another road.
+	ManeuverTurn ManeuverType = "turn"
+	// ManeuverContinue continues straight onto another road.
+	ManeuverContinue ManeuverType = "continue"
+	// ManeuverRoundabout enters a roundabout and leaves it at an exit.
+	ManeuverRoundabout ManeuverType = "roundabout"
+	// ManeuverArrive ends the route.
+	ManeuverArrive ManeuverType = "arrive"
+)
+
+// Modifier describes the direction of a turn.
+type Modifier string
+
+// Turns with an angle below 20 degrees go straight, slight turns have an angle
+// below 45 degrees, sharp turns above 135 degrees and U-turns above 170
+// degrees.
+const (
+	ModifierStraight    Modifier = "straight"
+	ModifierSlightLeft  Modifier = "slight left"
+	ModifierLeft        Modifier = "left"
+	ModifierSharpLeft   Modifier = "sharp left"
+	ModifierSlightRight Modifier = "slight right"
+	ModifierRight       Modifier = "right"
+	ModifierSharpRight  Modifier = "sharp right"
+	ModifierUTurn       Modifier = "u-turn"
+)
+
+// Maneuver is a single instruction of turn-by-turn directions.
+type Maneuver struct {
+	Type ManeuverType
+	// Modifier is the direction of a turn. It is empty for departures,
+	// arrivals and roundabouts.
+	Modifier Modifier
+	// Angle is the turn angle in degrees, negative for left and positive for
+	// right turns.
+	Angle float64
+	// Bearing is the direction of travel after the maneuver in degrees
+	// clockwise from north.
+	Bearing float64
+	// Exit is the number of the exit taken at a roundabout.
+	Exit int
+	// Road and Ref are the name and ref of the road followed after the
+	// maneuver.
+	Road string
+	Ref  string
+	// Location is the point at which the maneuver takes place.
+	Location Point
+	// Distance and Duration are the length in meters and the travel time in
+	// milliseconds until the next maneuver.
+	Distance uint32
+	Duration uint32
+	// Instruction describes the maneuver in English.
+	Instruction string
+}
+
+// road is a run of consecutive segments on the same road or roundabout.
+type road struct {
+	segments []Segment
+}
+
+func (r road) first() Segment {
+	return r.segments[0]
+}
+
+func (r road) last() Segment {
+	return r.segments[len(r.segments)-1]
+}
+
+func (r road) totals() (uint32, uint32) {
+	var distance, duration uint32
+	for _, s := range r.segments {
+		distance += s.Distance
+		duration += s.Duration
+	}
+	return distance, duration
+}
+
+// sameRoad reports whether b continues the road of a. Roads without a name or
+// ref are told apart by their OSM way or, where one way ends and the next
+// begins, by their highway class and whether the route goes straight on.
+func sameRoad(a, b Segment) bool {
+	if a.Roundabout || b.Roundabout {
+		return a.Roundabout == b.Roundabout
+	}
+	if a.Name != b.Name || a.Ref != b.Ref {
+		return false
+	}
+	if a.Name != "" || a.Ref != "" {
+		return true
+	}
+	return a.WayID == b.WayID ||
+		a.Highway == b.Highway && modifier(turnAngle(a, b)) == ModifierStraight
+}
+
+func roads(segments []Segment) []road {
+	var roads []road
+	for i, s := range segments {
+		if i == 0 || !sameRoad(segments[i-1], s) {
+			roads = append(roads, road{})
+		}
+		roads[len(roads)-1].segments = append(roads[len(roads)-1].segments, s)
+	}
+	return roads
+}
+
+// Directions turns the route into turn-by-turn maneuvers. It relies on the
+// Segments of the route, so it returns nil for routes not found by FindRoute
+// and for routes without segments.
+func (r RouteResult) Directions() []Maneuver {
+	roads := roads(r.Segments)
+	if len(roads) == 0 {
+		return nil
+	}
+
+	maneuvers := make([]Maneuver, 0, len(roads)+1)
+	for i := 0; i < len(roads); i++ {
+		current := roads[i]
+		m := Maneuver{
+			Road:     current.first().Name,
+			Ref:      current.first().Ref,
+			Location: current.first().From,
+			Bearing:  current.first().StartBearing,
+		}
+		m.Distance, m.Duration = current.totals()
+
+		switch {
+		case i == 0:
+			m.Type = ManeuverDepart
+		case current.first().Roundabout:
+			m.Type = ManeuverRoundabout
+			m.Angle = turnAngle(roads[i-1].last(), current.first())
+			m.Exit = exit(current)
+			m.Road, m.Ref = "", ""
+			// the road taken at the exit is part of the maneuver
+			if i+1 < len(roads) {
+				i++
+				next := roads[i]
+				m.Road, m.Ref = next.first().Name, next.first().Ref
+				distance, duration := next.totals()
+				m.Distance += distance
+				m.Duration += duration
+			}
+		default:
+			m.Angle = turnAngle(roads[i-1].last(), current.first())
+			m.Modifier = modifier(m.Angle)
+			m.Type = ManeuverTurn
+			if m.Modifier == ModifierStraight {
+				m.Type = ManeuverContinue
+			}
+		}
+		m.Instruction = m.instruction()
+		maneuvers = append(maneuvers, m)
+	}
+
+	arrival := Maneuver{
+		Type:     ManeuverArrive,
+		Location: roads[len(roads)-1].last().To,
+	}
+	arrival.Instruction = arrival.instruction()
+	return append(maneuvers, arrival)
+}
+
+// exit returns the number of the exit at which the route leaves the
+// roundabout.
+func exit(roundabout road) int {
+	exit := 1
+	for _, s := range roundabout.segments[:len(roundabout.segments)-1] {
+		// one of the branches continues along the roundabout
+		if s.Branches > 1 {
+			exit += s.Branches - 1
+		}
+	}
+	return exit
+}
+
+// turnAngle returns the angle in degrees between the direction of travel at
+// the end of from and at the start of to, within (-180, 180].
+func turnAngle(from, to Segment) float64 {
+	angle := to.StartBearing - from.EndBearing
+	for angle <= -180 {
+		angle += 360
+	}
+	for angle > 180 {
+		angle -= 360
+	}
+	return angle
+}
+
+func modifier(angle float64) Modifier {
+	a := math.Abs(angle)
+	switch {
+	case a < 20:
+		return ModifierStraight
+	case a >= 170:
+		return ModifierUTurn
+	case a < 45:
+		if angle < 0 {
+			return ModifierSlightLeft
+		}
+		return ModifierSlightRight
+	case a < 135:
+		if angle < 0 {
+			return ModifierLeft
+		}
+		return ModifierRight
+	default:
+		if angle < 0 {
+			return ModifierSharpLeft
+		}
+		return ModifierSharpRight
+	}
+}
+
+var compassPoints = []string{
+	"north", "northeast", "east", "southeast", "south", "southwest", "west", "northwest",
+}
+
+func compass(bearing float64) string {
+	return compassPoints[int(math.Floor(bearing/45+0.5))%len(compassPoints)]
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// roadName returns the name of the road, falling back to its ref.
+func (m Maneuver) roadName() string {
+	if m.Road != "" {
+		return m.Road
+	}
+	return m.Ref
+}
+
+func (m Maneuver) instruction() string {
+	onto := ""
+	if name := m.roadName(); name != "" {
+		onto = " onto " + name
+	}
+	switch m.Type {
+	case ManeuverDepart:
+		if name := m.roadName(); name != "" {
+			return fmt.Sprintf("Head %s on %s", compass(m.Bearing), name)
+		}
+		return fmt.Sprintf("Head %s", compass(m.Bearing))
+	case ManeuverContinue:
+		return "Continue" + onto
+	case ManeuverTurn:
+		if m.Modifier == ModifierUTurn {
+			return "Make a U-turn" + onto
+		}
+		return fmt.Sprintf("Turn %s%s", m.Modifier, onto)
+	case ManeuverRoundabout:
+		return fmt.Sprintf("At the roundabout, take the %s exit%s", ordinal(m.Exit), onto)
+	case ManeuverArrive:
+		return "Arrive at your destination"
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package routingkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// NearbyFacility is a facility found by NearestFacilities: the index of the
+// facility in the points the FacilityIndex was built from, and the cost to
+// reach it.
+type NearbyFacility struct {
+	Index int
+	Cost  uint32
+}
+
+// FacilityIndex holds the backward searches on the contraction hierarchy from
+// a set of facilities, e.g. stores or depots, so that the facilities nearest to
+// a point are found with a single forward search from the point instead of a
+// search to every facility. An index must be deleted with Delete once it is no
+// longer needed, and before the client it was built with is deleted.
+type FacilityIndex struct {
+	client  client
+	buckets routingkit.TargetBuckets
+	count   int
+}
+
+// NewFacilityIndex builds an index of the facilities. The facilities are
+// snapped with the snap radius of the client at the time the index is built;
+// facilities that cannot be snapped are never found. It returns an error if a
+// facility is invalid or if ctx is done before a free query slot becomes
+// available.
+func (c client) NewFacilityIndex(ctx context.Context, facilities []Point) (*FacilityIndex, error) {
+	if err := validatePoints(facilities...); err != nil {
+		return nil, err
+	}
+	buckets, err := c.targetBuckets(ctx, facilities)
+	if err != nil {
+		return nil, err
+	}
+	return &FacilityIndex{client: c, buckets: buckets, count: len(facilities)}, nil
+}
+
+// Len returns the number of facilities the index was built from.
+func (f *FacilityIndex) Len() int {
+	return f.count
+}
+
+// NearestFacilities returns up to k facilities that can be reached from the
+// point at a cost of at most maxCost, ordered by cost and, for equal costs, by
+// index. Passing MaxDistance as maxCost finds the k nearest reachable
+// facilities regardless of their cost: the search from the point stops once the
+// k nearest facilities are known, and a lower bound only stops it earlier. If
+// the point cannot be snapped, no facilities are returned.
+//
+// It returns an error if the point is invalid, if k is negative or if ctx is
+// done before a free query slot becomes available.
+func (f *FacilityIndex) NearestFacilities(
+	ctx context.Context,
+	point Point,
+	k int,
+	maxCost uint32,
+) ([]NearbyFacility, error) {
+	if err := point.Validate(); err != nil {
+		return nil, err
+	}
+	if k < 0 {
+		return nil, fmt.Errorf("number of facilities must not be negative, got %d", k)
+	}
+	c := f.client
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(counter)
+	if k == 0 || f.count == 0 {
+		return []NearbyFacility{}, nil
+	}
+	source := routingkit.NewPoint()
+	defer routingkit.DeletePoint(source)
+	source.SetLon(point.Lon)
+	source.SetLat(point.Lat)
+	resp := c.client.Nearest_targets(counter, f.buckets, c.snapRadius, source, uint(k), uint(maxCost))
+	values := toUint32s(resp)
+	routingkit.DeleteUnsignedVector(resp)
+
+	facilities := make([]NearbyFacility, len(values)/2)
+	for i := range facilities {
+		facilities[i] = NearbyFacility{Index: int(values[2*i]), Cost: values[2*i+1]}
+	}
+	return facilities, nil
+}
+
+// Delete frees the memory held by the index.
+func (f *FacilityIndex) Delete() {
+	routingkit.DeleteTargetBuckets(f.buckets)
+}
+
+// NewFacilityIndex builds an index of the facilities, so that the facilities
+// with the lowest travel times from a point are found by NearestFacilities.
+func (c TravelTimeClient) NewFacilityIndex(ctx context.Context, facilities []Point) (*FacilityIndex, error) {
+	return c.client.NewFacilityIndex(ctx, facilities)
+}
@@ -60,10 +60,36 @@ typedef long long swig_type_24;
 typedef long long swig_type_25;
 typedef long long swig_type_26;
 typedef long long swig_type_27;
-typedef _gostring_ swig_type_28;
-typedef _gostring_ swig_type_29;
-typedef _gostring_ swig_type_30;
-typedef _gostring_ swig_type_31;
+typedef long long swig_type_28;
+typedef long long swig_type_29;
+typedef long long swig_type_30;
+typedef long long swig_type_31;
+typedef long long swig_type_32;
+typedef long long swig_type_33;
+typedef long long swig_type_34;
+typedef long long swig_type_35;
+typedef long long swig_type_36;
+typedef long long swig_type_37;
+typedef _gostring_ swig_type_38;
+typedef _gostring_ swig_type_39;
+typedef _gostring_ swig_type_40;
+typedef _gostring_ swig_type_41;
+typedef _gostring_ swig_type_42;
+typedef _gostring_ swig_type_43;
+typedef long long swig_type_44;
+typedef long long swig_type_45;
+typedef _gostring_ swig_type_46;
+typedef _gostring_ swig_type_47;
+typedef _gostring_ swig_type_48;
+typedef _gostring_ swig_type_49;
+typedef _gostring_ swig_type_50;
+typedef _gostring_ swig_type_51;
+typedef long long swig_type_52;
+typedef long long swig_type_53;
+typedef _gostring_ swig_type_54;
+typedef _gostring_ swig_type_55;
+typedef _gostring_ swig_type_56;
+typedef _gostring_ swig_type_57;
 extern void _wrap_Swig_free_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_Swig_malloc_routingkit_75139fcf52884c4c(swig_intgo arg1);
 extern uintptr_t _wrap_new_IntVector__SWIG_0_routingkit_75139fcf52884c4c(void);
@@ -102,12 +128,36 @@ extern void _wrap_PointVector_add_routingkit_75139fcf52884c4c(uintptr_t arg1, ui
 extern uintptr_t _wrap_PointVector_get_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
 extern void _wrap_PointVector_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, uintptr_t arg3);
 extern void _wrap_delete_PointVector_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_SegmentVector__SWIG_0_routingkit_75139fcf52884c4c(void);
+extern uintptr_t _wrap_new_SegmentVector__SWIG_1_routingkit_75139fcf52884c4c(swig_type_13 arg1);
+extern uintptr_t _wrap_new_SegmentVector__SWIG_2_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_type_14 _wrap_SegmentVector_size_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_type_15 _wrap_SegmentVector_capacity_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SegmentVector_reserve_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_16 arg2);
+extern _Bool _wrap_SegmentVector_isEmpty_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SegmentVector_clear_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SegmentVector_add_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_SegmentVector_get_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern void _wrap_SegmentVector_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, uintptr_t arg3);
+extern void _wrap_delete_SegmentVector_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_QueryResponseVector__SWIG_0_routingkit_75139fcf52884c4c(void);
+extern uintptr_t _wrap_new_QueryResponseVector__SWIG_1_routingkit_75139fcf52884c4c(swig_type_17 arg1);
+extern uintptr_t _wrap_new_QueryResponseVector__SWIG_2_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_type_18 _wrap_QueryResponseVector_size_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_type_19 _wrap_QueryResponseVector_capacity_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponseVector_reserve_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_20 arg2);
+extern _Bool _wrap_QueryResponseVector_isEmpty_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponseVector_clear_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponseVector_add_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_QueryResponseVector_get_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern void _wrap_QueryResponseVector_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, uintptr_t arg3);
+extern void _wrap_delete_QueryResponseVector_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_new_UnsignedVector__SWIG_0_routingkit_75139fcf52884c4c(void);
-extern uintptr_t _wrap_new_UnsignedVector__SWIG_1_routingkit_75139fcf52884c4c(swig_type_13 arg1);
+extern uintptr_t _wrap_new_UnsignedVector__SWIG_1_routingkit_75139fcf52884c4c(swig_type_21 arg1);
 extern uintptr_t _wrap_new_UnsignedVector__SWIG_2_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern swig_type_14 _wrap_UnsignedVector_size_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern swig_type_15 _wrap_UnsignedVector_capacity_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern void _wrap_UnsignedVector_reserve_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_16 arg2);
+extern swig_type_22 _wrap_UnsignedVector_size_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_type_23 _wrap_UnsignedVector_capacity_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_UnsignedVector_reserve_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_24 arg2);
 extern _Bool _wrap_UnsignedVector_isEmpty_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_UnsignedVector_clear_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_UnsignedVector_add_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
@@ -115,26 +165,26 @@ extern swig_intgo _wrap_UnsignedVector_get_routingkit_75139fcf52884c4c(uintptr_t
 extern void _wrap_UnsignedVector_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, swig_intgo arg3);
 extern void _wrap_delete_UnsignedVector_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_new_LongIntVector__SWIG_0_routingkit_75139fcf52884c4c(void);
-extern uintptr_t _wrap_new_LongIntVector__SWIG_1_routingkit_75139fcf52884c4c(swig_type_17 arg1);
+extern uintptr_t _wrap_new_LongIntVector__SWIG_1_routingkit_75139fcf52884c4c(swig_type_25 arg1);
 extern uintptr_t _wrap_new_LongIntVector__SWIG_2_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern swig_type_18 _wrap_LongIntVector_size_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern swig_type_19 _wrap_LongIntVector_capacity_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern void _wrap_LongIntVector_reserve_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_20 arg2);
+extern swig_type_26 _wrap_LongIntVector_size_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_type_27 _wrap_LongIntVector_capacity_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_LongIntVector_reserve_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_28 arg2);
 extern _Bool _wrap_LongIntVector_isEmpty_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_LongIntVector_clear_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern void _wrap_LongIntVector_add_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_21 arg2);
-extern swig_type_22 _wrap_LongIntVector_get_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
-extern void _wrap_LongIntVector_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, swig_type_23 arg3);
+extern void _wrap_LongIntVector_add_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_29 arg2);
+extern swig_type_30 _wrap_LongIntVector_get_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern void _wrap_LongIntVector_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, swig_type_31 arg3);
 extern void _wrap_delete_LongIntVector_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_new_IntIntMap__SWIG_0_routingkit_75139fcf52884c4c(void);
 extern uintptr_t _wrap_new_IntIntMap__SWIG_1_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern swig_intgo _wrap_IntIntMap_size_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern _Bool _wrap_IntIntMap_empty_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_IntIntMap_clear_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern swig_intgo _wrap_IntIntMap_get_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_24 arg2);
-extern void _wrap_IntIntMap_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_25 arg2, swig_intgo arg3);
-extern void _wrap_IntIntMap_del_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_26 arg2);
-extern _Bool _wrap_IntIntMap_has_key_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_27 arg2);
+extern swig_intgo _wrap_IntIntMap_get_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_32 arg2);
+extern void _wrap_IntIntMap_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_33 arg2, swig_intgo arg3);
+extern void _wrap_IntIntMap_del_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_34 arg2);
+extern _Bool _wrap_IntIntMap_has_key_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_35 arg2);
 extern void _wrap_delete_IntIntMap_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_Point_lon_set_routingkit_75139fcf52884c4c(uintptr_t arg1, float arg2);
 extern float _wrap_Point_lon_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
@@ -142,12 +192,102 @@ extern void _wrap_Point_lat_set_routingkit_75139fcf52884c4c(uintptr_t arg1, floa
 extern float _wrap_Point_lat_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_new_Point_routingkit_75139fcf52884c4c(void);
 extern void _wrap_delete_Point_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_intgo _wrap_status_ok_routingkit_75139fcf52884c4c(void);
+extern swig_intgo _wrap_status_source_not_snapped_routingkit_75139fcf52884c4c(void);
+extern swig_intgo _wrap_status_target_not_snapped_routingkit_75139fcf52884c4c(void);
+extern swig_intgo _wrap_status_no_path_routingkit_75139fcf52884c4c(void);
+extern void _wrap_Segment_way_id_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_36 arg2);
+extern swig_type_37 _wrap_Segment_way_id_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_name_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_38 arg2);
+extern swig_type_39 _wrap_Segment_name_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_ref_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_40 arg2);
+extern swig_type_41 _wrap_Segment_ref_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_highway_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_42 arg2);
+extern swig_type_43 _wrap_Segment_highway_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_distance_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_Segment_distance_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_duration_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_Segment_duration_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_speed_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_Segment_speed_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_from_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_Segment_from_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_to_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_Segment_to_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_start_bearing_set_routingkit_75139fcf52884c4c(uintptr_t arg1, float arg2);
+extern float _wrap_Segment_start_bearing_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_end_bearing_set_routingkit_75139fcf52884c4c(uintptr_t arg1, float arg2);
+extern float _wrap_Segment_end_bearing_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_roundabout_set_routingkit_75139fcf52884c4c(uintptr_t arg1, _Bool arg2);
+extern _Bool _wrap_Segment_roundabout_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Segment_branches_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_Segment_branches_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_Segment_routingkit_75139fcf52884c4c(void);
+extern void _wrap_delete_Segment_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_status_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_QueryResponse_status_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_QueryResponse_distance_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
 extern swig_intgo _wrap_QueryResponse_distance_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_geo_distance_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_QueryResponse_geo_distance_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_travel_time_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_QueryResponse_travel_time_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_QueryResponse_waypoints_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
 extern uintptr_t _wrap_QueryResponse_waypoints_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_source_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_QueryResponse_source_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_target_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_QueryResponse_target_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_segments_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_QueryResponse_segments_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_last_arc_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_QueryResponse_last_arc_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_QueryResponse_last_fraction_set_routingkit_75139fcf52884c4c(uintptr_t arg1, float arg2);
+extern float _wrap_QueryResponse_last_fraction_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_new_QueryResponse_routingkit_75139fcf52884c4c(void);
 extern void _wrap_delete_QueryResponse_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SnapResponse_snapped_set_routingkit_75139fcf52884c4c(uintptr_t arg1, _Bool arg2);
+extern _Bool _wrap_SnapResponse_snapped_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SnapResponse_point_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_SnapResponse_point_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SnapResponse_distance_set_routingkit_75139fcf52884c4c(uintptr_t arg1, float arg2);
+extern float _wrap_SnapResponse_distance_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SnapResponse_way_id_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_44 arg2);
+extern swig_type_45 _wrap_SnapResponse_way_id_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SnapResponse_name_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_46 arg2);
+extern swig_type_47 _wrap_SnapResponse_name_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_SnapResponse_highway_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_48 arg2);
+extern swig_type_49 _wrap_SnapResponse_highway_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_SnapResponse_routingkit_75139fcf52884c4c(void);
+extern void _wrap_delete_SnapResponse_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_DistancesResponse_distances_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_DistancesResponse_distances_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_DistancesResponse_statuses_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_DistancesResponse_statuses_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_DistancesResponse_geo_distances_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_DistancesResponse_geo_distances_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_DistancesResponse_travel_times_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_DistancesResponse_travel_times_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_DistancesResponse_routingkit_75139fcf52884c4c(void);
+extern void _wrap_delete_DistancesResponse_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_ReachResponse_points_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_ReachResponse_points_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_ReachResponse_costs_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_ReachResponse_costs_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_ReachResponse_routingkit_75139fcf52884c4c(void);
+extern void _wrap_delete_ReachResponse_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_MatchResponse_points_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_MatchResponse_points_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_MatchResponse_matched_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_MatchResponse_matched_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_MatchResponse_confidences_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_MatchResponse_confidences_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_MatchResponse_path_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_MatchResponse_path_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_MatchResponse_distance_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_MatchResponse_distance_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_MatchResponse_routingkit_75139fcf52884c4c(void);
+extern void _wrap_delete_MatchResponse_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern swig_intgo _wrap_vehicle_routingkit_75139fcf52884c4c(void);
 extern swig_intgo _wrap_bike_routingkit_75139fcf52884c4c(void);
 extern swig_intgo _wrap_pedestrian_routingkit_75139fcf52884c4c(void);
@@ -157,17 +297,17 @@ extern void _wrap_Profile_waySpeeds_set_routingkit_75139fcf52884c4c(uintptr_t ar
 extern uintptr_t _wrap_Profile_waySpeeds_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_Profile_transportMode_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2);
 extern swig_intgo _wrap_Profile_transportMode_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern void _wrap_Profile_name_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_28 arg2);
-extern swig_type_29 _wrap_Profile_name_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Profile_name_set_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_type_50 arg2);
+extern swig_type_51 _wrap_Profile_name_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_Profile_prevent_left_turns_set_routingkit_75139fcf52884c4c(uintptr_t arg1, _Bool arg2);
 extern _Bool _wrap_Profile_prevent_left_turns_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_Profile_prevent_u_turns_set_routingkit_75139fcf52884c4c(uintptr_t arg1, _Bool arg2);
 extern _Bool _wrap_Profile_prevent_u_turns_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern void _wrap_Profile_travel_time_set_routingkit_75139fcf52884c4c(uintptr_t arg1, _Bool arg2);
-extern _Bool _wrap_Profile_travel_time_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_new_Profile_routingkit_75139fcf52884c4c(void);
 extern void _wrap_delete_Profile_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern swig_intgo _wrap_max_distance_get_routingkit_75139fcf52884c4c(void);
+extern swig_intgo _wrap_invalid_arc_get_routingkit_75139fcf52884c4c(void);
+extern void _wrap_install_crash_handlers_routingkit_75139fcf52884c4c(swig_intgo arg1, swig_intgo arg2, swig_type_52 arg3, swig_type_53 arg4);
 extern void _wrap_RoutingGraph_first_out_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
 extern uintptr_t _wrap_RoutingGraph_first_out_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_RoutingGraph_head_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
@@ -184,14 +324,44 @@ extern void _wrap_RoutingGraph_forbidden_turn_from_arc_set_routingkit_75139fcf52
 extern uintptr_t _wrap_RoutingGraph_forbidden_turn_from_arc_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_RoutingGraph_forbidden_turn_to_arc_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
 extern uintptr_t _wrap_RoutingGraph_forbidden_turn_to_arc_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_RoutingGraph_way_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_RoutingGraph_way_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_RoutingGraph_way_speed_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_RoutingGraph_way_speed_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_RoutingGraph_first_modelling_byte_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_RoutingGraph_first_modelling_byte_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_RoutingGraph_modelling_bytes_set_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_RoutingGraph_modelling_bytes_get_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern swig_intgo _wrap_RoutingGraph_node_count_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern swig_intgo _wrap_RoutingGraph_arc_count_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern uintptr_t _wrap_new_RoutingGraph_routingkit_75139fcf52884c4c(void);
 extern void _wrap_delete_RoutingGraph_routingkit_75139fcf52884c4c(uintptr_t arg1);
-extern uintptr_t _wrap_Client_query_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, float arg4, float arg5, float arg6, float arg7, _Bool arg8);
-extern uintptr_t _wrap_Client_distances_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, uintptr_t arg4, uintptr_t arg5);
+extern uintptr_t _wrap_new_RoadNetwork_routingkit_75139fcf52884c4c(swig_type_54 arg1, uintptr_t arg2, _Bool arg3);
+extern swig_type_55 _wrap_RoadNetwork_load_error_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_delete_RoadNetwork_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern swig_intgo _wrap_TargetBuckets_target_count_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_new_TargetBuckets_routingkit_75139fcf52884c4c(void);
+extern void _wrap_delete_TargetBuckets_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern uintptr_t _wrap_Client_query_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, float arg4, float arg5, float arg6, float arg7, float arg8, float arg9, float arg10, float arg11, _Bool arg12, _Bool arg13);
+extern uintptr_t _wrap_Client_continue_query_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, swig_intgo arg4, float arg5, float arg6, float arg7, _Bool arg8, _Bool arg9);
+extern uintptr_t _wrap_Client_alternatives_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, float arg4, float arg5, float arg6, float arg7, swig_intgo arg8, float arg9, float arg10, _Bool arg11, _Bool arg12);
+extern swig_intgo _wrap_Client_many_to_many_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2, float arg3, uintptr_t arg4, uintptr_t arg5, uintptr_t arg6, uintptr_t arg7, uintptr_t arg8, uintptr_t arg9, uintptr_t arg10);
+extern uintptr_t _wrap_Client_target_buckets_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2, float arg3, uintptr_t arg4, swig_intgo arg5);
+extern swig_intgo _wrap_Client_many_to_many_rows_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2, uintptr_t arg3, float arg4, uintptr_t arg5, uintptr_t arg6, uintptr_t arg7, uintptr_t arg8, uintptr_t arg9, uintptr_t arg10);
+extern uintptr_t _wrap_Client_sparse_many_to_many_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2, uintptr_t arg3, float arg4, uintptr_t arg5, swig_intgo arg6, uintptr_t arg7);
+extern uintptr_t _wrap_Client_nearest_targets_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, uintptr_t arg3, float arg4, uintptr_t arg5, swig_intgo arg6, swig_intgo arg7);
+extern swig_intgo _wrap_Client_node_count_routingkit_75139fcf52884c4c(uintptr_t arg1);
+extern void _wrap_Client_node_positions_routingkit_75139fcf52884c4c(uintptr_t arg1, uintptr_t arg2, uintptr_t arg3);
+extern _Bool _wrap_Client_one_to_all_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, float arg4, float arg5, uintptr_t arg6, uintptr_t arg7);
+extern void _wrap_Client_sample_costs_routingkit_75139fcf52884c4c(uintptr_t arg1, float arg2, float arg3, float arg4, uintptr_t arg5, uintptr_t arg6, uintptr_t arg7);
+extern uintptr_t _wrap_Client_reachable_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, float arg4, float arg5, swig_intgo arg6, float arg7, _Bool arg8, uintptr_t arg9);
+extern uintptr_t _wrap_Client_map_match_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, uintptr_t arg4, uintptr_t arg5, float arg6, float arg7, float arg8, swig_intgo arg9);
+extern uintptr_t _wrap_Client_distances_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, uintptr_t arg4, float arg5, float arg6, uintptr_t arg7, uintptr_t arg8, uintptr_t arg9);
+extern uintptr_t _wrap_Client_detailed_distances_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, uintptr_t arg4, uintptr_t arg5, _Bool arg6);
 extern uintptr_t _wrap_Client_nearest_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, float arg4, float arg5);
-extern uintptr_t _wrap_new_Client_routingkit_75139fcf52884c4c(swig_intgo arg1, swig_type_30 arg2, swig_type_31 arg3, uintptr_t arg4);
+extern uintptr_t _wrap_Client_snap_routingkit_75139fcf52884c4c(uintptr_t arg1, swig_intgo arg2, float arg3, float arg4, float arg5);
+extern uintptr_t _wrap_new_Client_routingkit_75139fcf52884c4c(swig_intgo arg1, uintptr_t arg2, swig_type_56 arg3, _Bool arg4, _Bool arg5);
+extern swig_type_57 _wrap_Client_load_error_routingkit_75139fcf52884c4c(uintptr_t arg1);
 extern void _wrap_delete_Client_routingkit_75139fcf52884c4c(uintptr_t arg1);
 #undef intgo
 */
@@ -591,6 +761,242 @@ type PointVector interface {
 	Set(arg2 int, arg3 Point)
 }
 
+type SwigcptrSegmentVector uintptr
+
+func (p SwigcptrSegmentVector) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrSegmentVector) SwigIsSegmentVector() {
+}
+
+func NewSegmentVector__SWIG_0() (_swig_ret SegmentVector) {
+	var swig_r SegmentVector
+	swig_r = (SegmentVector)(SwigcptrSegmentVector(C._wrap_new_SegmentVector__SWIG_0_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func NewSegmentVector__SWIG_1(arg1 int64) (_swig_ret SegmentVector) {
+	var swig_r SegmentVector
+	_swig_i_0 := arg1
+	swig_r = (SegmentVector)(SwigcptrSegmentVector(C._wrap_new_SegmentVector__SWIG_1_routingkit_75139fcf52884c4c(C.swig_type_13(_swig_i_0))))
+	return swig_r
+}
+
+func NewSegmentVector__SWIG_2(arg1 SegmentVector) (_swig_ret SegmentVector) {
+	var swig_r SegmentVector
+	_swig_i_0 := arg1.Swigcptr()
+	swig_r = (SegmentVector)(SwigcptrSegmentVector(C._wrap_new_SegmentVector__SWIG_2_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func NewSegmentVector(a ...interface{}) SegmentVector {
+	argc := len(a)
+	if argc == 0 {
+		return NewSegmentVector__SWIG_0()
+	}
+	if argc == 1 {
+		if _, ok := a[0].(int64); !ok {
+			goto check_2
+		}
+		return NewSegmentVector__SWIG_1(a[0].(int64))
+	}
+check_2:
+	if argc == 1 {
+		return NewSegmentVector__SWIG_2(a[0].(SegmentVector))
+	}
+	panic("No match for overloaded function call")
+}
+
+func (arg1 SwigcptrSegmentVector) Size() (_swig_ret int64) {
+	var swig_r int64
+	_swig_i_0 := arg1
+	swig_r = (int64)(C._wrap_SegmentVector_size_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegmentVector) Capacity() (_swig_ret int64) {
+	var swig_r int64
+	_swig_i_0 := arg1
+	swig_r = (int64)(C._wrap_SegmentVector_capacity_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegmentVector) Reserve(arg2 int64) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_SegmentVector_reserve_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_16(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegmentVector) IsEmpty() (_swig_ret bool) {
+	var swig_r bool
+	_swig_i_0 := arg1
+	swig_r = (bool)(C._wrap_SegmentVector_isEmpty_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegmentVector) Clear() {
+	_swig_i_0 := arg1
+	C._wrap_SegmentVector_clear_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+func (arg1 SwigcptrSegmentVector) Add(arg2 Segment) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_SegmentVector_add_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegmentVector) Get(arg2 int) (_swig_ret Segment) {
+	var swig_r Segment
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	swig_r = (Segment)(SwigcptrSegment(C._wrap_SegmentVector_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegmentVector) Set(arg2 int, arg3 Segment) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3.Swigcptr()
+	C._wrap_SegmentVector_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.uintptr_t(_swig_i_2))
+}
+
+func DeleteSegmentVector(arg1 SegmentVector) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_SegmentVector_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type SegmentVector interface {
+	Swigcptr() uintptr
+	SwigIsSegmentVector()
+	Size() (_swig_ret int64)
+	Capacity() (_swig_ret int64)
+	Reserve(arg2 int64)
+	IsEmpty() (_swig_ret bool)
+	Clear()
+	Add(arg2 Segment)
+	Get(arg2 int) (_swig_ret Segment)
+	Set(arg2 int, arg3 Segment)
+}
+
+type SwigcptrQueryResponseVector uintptr
+
+func (p SwigcptrQueryResponseVector) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrQueryResponseVector) SwigIsQueryResponseVector() {
+}
+
+func NewQueryResponseVector__SWIG_0() (_swig_ret QueryResponseVector) {
+	var swig_r QueryResponseVector
+	swig_r = (QueryResponseVector)(SwigcptrQueryResponseVector(C._wrap_new_QueryResponseVector__SWIG_0_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func NewQueryResponseVector__SWIG_1(arg1 int64) (_swig_ret QueryResponseVector) {
+	var swig_r QueryResponseVector
+	_swig_i_0 := arg1
+	swig_r = (QueryResponseVector)(SwigcptrQueryResponseVector(C._wrap_new_QueryResponseVector__SWIG_1_routingkit_75139fcf52884c4c(C.swig_type_17(_swig_i_0))))
+	return swig_r
+}
+
+func NewQueryResponseVector__SWIG_2(arg1 QueryResponseVector) (_swig_ret QueryResponseVector) {
+	var swig_r QueryResponseVector
+	_swig_i_0 := arg1.Swigcptr()
+	swig_r = (QueryResponseVector)(SwigcptrQueryResponseVector(C._wrap_new_QueryResponseVector__SWIG_2_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func NewQueryResponseVector(a ...interface{}) QueryResponseVector {
+	argc := len(a)
+	if argc == 0 {
+		return NewQueryResponseVector__SWIG_0()
+	}
+	if argc == 1 {
+		if _, ok := a[0].(int64); !ok {
+			goto check_2
+		}
+		return NewQueryResponseVector__SWIG_1(a[0].(int64))
+	}
+check_2:
+	if argc == 1 {
+		return NewQueryResponseVector__SWIG_2(a[0].(QueryResponseVector))
+	}
+	panic("No match for overloaded function call")
+}
+
+func (arg1 SwigcptrQueryResponseVector) Size() (_swig_ret int64) {
+	var swig_r int64
+	_swig_i_0 := arg1
+	swig_r = (int64)(C._wrap_QueryResponseVector_size_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrQueryResponseVector) Capacity() (_swig_ret int64) {
+	var swig_r int64
+	_swig_i_0 := arg1
+	swig_r = (int64)(C._wrap_QueryResponseVector_capacity_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrQueryResponseVector) Reserve(arg2 int64) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_QueryResponseVector_reserve_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_20(_swig_i_1))
+}
+
+func (arg1 SwigcptrQueryResponseVector) IsEmpty() (_swig_ret bool) {
+	var swig_r bool
+	_swig_i_0 := arg1
+	swig_r = (bool)(C._wrap_QueryResponseVector_isEmpty_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrQueryResponseVector) Clear() {
+	_swig_i_0 := arg1
+	C._wrap_QueryResponseVector_clear_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+func (arg1 SwigcptrQueryResponseVector) Add(arg2 QueryResponse) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_QueryResponseVector_add_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrQueryResponseVector) Get(arg2 int) (_swig_ret QueryResponse) {
+	var swig_r QueryResponse
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	swig_r = (QueryResponse)(SwigcptrQueryResponse(C._wrap_QueryResponseVector_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))))
+	return swig_r
+}
+
+func (arg1 SwigcptrQueryResponseVector) Set(arg2 int, arg3 QueryResponse) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3.Swigcptr()
+	C._wrap_QueryResponseVector_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.uintptr_t(_swig_i_2))
+}
+
+func DeleteQueryResponseVector(arg1 QueryResponseVector) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_QueryResponseVector_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type QueryResponseVector interface {
+	Swigcptr() uintptr
+	SwigIsQueryResponseVector()
+	Size() (_swig_ret int64)
+	Capacity() (_swig_ret int64)
+	Reserve(arg2 int64)
+	IsEmpty() (_swig_ret bool)
+	Clear()
+	Add(arg2 QueryResponse)
+	Get(arg2 int) (_swig_ret QueryResponse)
+	Set(arg2 int, arg3 QueryResponse)
+}
+
 type SwigcptrUnsignedVector uintptr
 
 func (p SwigcptrUnsignedVector) Swigcptr() uintptr {
@@ -609,7 +1015,7 @@ func NewUnsignedVector__SWIG_0() (_swig_ret UnsignedVector) {
 func NewUnsignedVector__SWIG_1(arg1 int64) (_swig_ret UnsignedVector) {
 	var swig_r UnsignedVector
 	_swig_i_0 := arg1
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_new_UnsignedVector__SWIG_1_routingkit_75139fcf52884c4c(C.swig_type_13(_swig_i_0))))
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_new_UnsignedVector__SWIG_1_routingkit_75139fcf52884c4c(C.swig_type_21(_swig_i_0))))
 	return swig_r
 }
 
@@ -655,7 +1061,7 @@ func (arg1 SwigcptrUnsignedVector) Capacity() (_swig_ret int64) {
 func (arg1 SwigcptrUnsignedVector) Reserve(arg2 int64) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_UnsignedVector_reserve_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_16(_swig_i_1))
+	C._wrap_UnsignedVector_reserve_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_24(_swig_i_1))
 }
 
 func (arg1 SwigcptrUnsignedVector) IsEmpty() (_swig_ret bool) {
@@ -727,7 +1133,7 @@ func NewLongIntVector__SWIG_0() (_swig_ret LongIntVector) {
 func NewLongIntVector__SWIG_1(arg1 int64) (_swig_ret LongIntVector) {
 	var swig_r LongIntVector
 	_swig_i_0 := arg1
-	swig_r = (LongIntVector)(SwigcptrLongIntVector(C._wrap_new_LongIntVector__SWIG_1_routingkit_75139fcf52884c4c(C.swig_type_17(_swig_i_0))))
+	swig_r = (LongIntVector)(SwigcptrLongIntVector(C._wrap_new_LongIntVector__SWIG_1_routingkit_75139fcf52884c4c(C.swig_type_25(_swig_i_0))))
 	return swig_r
 }
 
@@ -773,7 +1179,7 @@ func (arg1 SwigcptrLongIntVector) Capacity() (_swig_ret int64) {
 func (arg1 SwigcptrLongIntVector) Reserve(arg2 int64) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_LongIntVector_reserve_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_20(_swig_i_1))
+	C._wrap_LongIntVector_reserve_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_28(_swig_i_1))
 }
 
 func (arg1 SwigcptrLongIntVector) IsEmpty() (_swig_ret bool) {
@@ -791,7 +1197,7 @@ func (arg1 SwigcptrLongIntVector) Clear() {
 func (arg1 SwigcptrLongIntVector) Add(arg2 int64) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_LongIntVector_add_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_21(_swig_i_1))
+	C._wrap_LongIntVector_add_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_29(_swig_i_1))
 }
 
 func (arg1 SwigcptrLongIntVector) Get(arg2 int) (_swig_ret int64) {
@@ -806,7 +1212,7 @@ func (arg1 SwigcptrLongIntVector) Set(arg2 int, arg3 int64) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	C._wrap_LongIntVector_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.swig_type_23(_swig_i_2))
+	C._wrap_LongIntVector_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.swig_type_31(_swig_i_2))
 }
 
 func DeleteLongIntVector(arg1 LongIntVector) {
@@ -883,7 +1289,7 @@ func (arg1 SwigcptrIntIntMap) Get(arg2 uint64) (_swig_ret uint) {
 	var swig_r uint
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (uint)(C._wrap_IntIntMap_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_24(_swig_i_1)))
+	swig_r = (uint)(C._wrap_IntIntMap_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_32(_swig_i_1)))
 	return swig_r
 }
 
@@ -891,20 +1297,20 @@ func (arg1 SwigcptrIntIntMap) Set(arg2 uint64, arg3 uint) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	C._wrap_IntIntMap_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_25(_swig_i_1), C.swig_intgo(_swig_i_2))
+	C._wrap_IntIntMap_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_33(_swig_i_1), C.swig_intgo(_swig_i_2))
 }
 
 func (arg1 SwigcptrIntIntMap) Del(arg2 uint64) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_IntIntMap_del_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_26(_swig_i_1))
+	C._wrap_IntIntMap_del_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_34(_swig_i_1))
 }
 
 func (arg1 SwigcptrIntIntMap) Has_key(arg2 uint64) (_swig_ret bool) {
 	var swig_r bool
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (bool)(C._wrap_IntIntMap_has_key_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_27(_swig_i_1)))
+	swig_r = (bool)(C._wrap_IntIntMap_has_key_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_35(_swig_i_1)))
 	return swig_r
 }
 
@@ -980,445 +1386,1543 @@ type Point interface {
 	GetLat() (_swig_ret float32)
 }
 
-type SwigcptrQueryResponse uintptr
-
-func (p SwigcptrQueryResponse) Swigcptr() uintptr {
-	return (uintptr)(p)
-}
-
-func (p SwigcptrQueryResponse) SwigIsQueryResponse() {
-}
-
-func (arg1 SwigcptrQueryResponse) SetDistance(arg2 uint) {
-	_swig_i_0 := arg1
-	_swig_i_1 := arg2
-	C._wrap_QueryResponse_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
-}
-
-func (arg1 SwigcptrQueryResponse) GetDistance() (_swig_ret uint) {
-	var swig_r uint
-	_swig_i_0 := arg1
-	swig_r = (uint)(C._wrap_QueryResponse_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
-	return swig_r
-}
-
-func (arg1 SwigcptrQueryResponse) SetWaypoints(arg2 PointVector) {
-	_swig_i_0 := arg1
-	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_QueryResponse_waypoints_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
-}
-
-func (arg1 SwigcptrQueryResponse) GetWaypoints() (_swig_ret PointVector) {
-	var swig_r PointVector
-	_swig_i_0 := arg1
-	swig_r = (PointVector)(SwigcptrPointVector(C._wrap_QueryResponse_waypoints_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
-	return swig_r
-}
-
-func NewQueryResponse() (_swig_ret QueryResponse) {
-	var swig_r QueryResponse
-	swig_r = (QueryResponse)(SwigcptrQueryResponse(C._wrap_new_QueryResponse_routingkit_75139fcf52884c4c()))
+type Query_status int
+func _swig_getstatus_ok() (_swig_ret Query_status) {
+	var swig_r Query_status
+	swig_r = (Query_status)(C._wrap_status_ok_routingkit_75139fcf52884c4c())
 	return swig_r
 }
 
-func DeleteQueryResponse(arg1 QueryResponse) {
-	_swig_i_0 := arg1.Swigcptr()
-	C._wrap_delete_QueryResponse_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
-}
-
-type QueryResponse interface {
-	Swigcptr() uintptr
-	SwigIsQueryResponse()
-	SetDistance(arg2 uint)
-	GetDistance() (_swig_ret uint)
-	SetWaypoints(arg2 PointVector)
-	GetWaypoints() (_swig_ret PointVector)
-}
-
-type Transport_mode int
-func _swig_getvehicle() (_swig_ret Transport_mode) {
-	var swig_r Transport_mode
-	swig_r = (Transport_mode)(C._wrap_vehicle_routingkit_75139fcf52884c4c())
+var Status_ok Query_status = _swig_getstatus_ok()
+func _swig_getstatus_source_not_snapped() (_swig_ret Query_status) {
+	var swig_r Query_status
+	swig_r = (Query_status)(C._wrap_status_source_not_snapped_routingkit_75139fcf52884c4c())
 	return swig_r
 }
 
-var Vehicle Transport_mode = _swig_getvehicle()
-func _swig_getbike() (_swig_ret Transport_mode) {
-	var swig_r Transport_mode
-	swig_r = (Transport_mode)(C._wrap_bike_routingkit_75139fcf52884c4c())
+var Status_source_not_snapped Query_status = _swig_getstatus_source_not_snapped()
+func _swig_getstatus_target_not_snapped() (_swig_ret Query_status) {
+	var swig_r Query_status
+	swig_r = (Query_status)(C._wrap_status_target_not_snapped_routingkit_75139fcf52884c4c())
 	return swig_r
 }
 
-var Bike Transport_mode = _swig_getbike()
-func _swig_getpedestrian() (_swig_ret Transport_mode) {
-	var swig_r Transport_mode
-	swig_r = (Transport_mode)(C._wrap_pedestrian_routingkit_75139fcf52884c4c())
+var Status_target_not_snapped Query_status = _swig_getstatus_target_not_snapped()
+func _swig_getstatus_no_path() (_swig_ret Query_status) {
+	var swig_r Query_status
+	swig_r = (Query_status)(C._wrap_status_no_path_routingkit_75139fcf52884c4c())
 	return swig_r
 }
 
-var Pedestrian Transport_mode = _swig_getpedestrian()
-type SwigcptrProfile uintptr
+var Status_no_path Query_status = _swig_getstatus_no_path()
+type SwigcptrSegment uintptr
 
-func (p SwigcptrProfile) Swigcptr() uintptr {
+func (p SwigcptrSegment) Swigcptr() uintptr {
 	return (uintptr)(p)
 }
 
-func (p SwigcptrProfile) SwigIsProfile() {
+func (p SwigcptrSegment) SwigIsSegment() {
 }
 
-func (arg1 SwigcptrProfile) SetAllowedWayIds(arg2 IntVector) {
+func (arg1 SwigcptrSegment) SetWay_id(arg2 int64) {
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_Profile_allowedWayIds_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	_swig_i_1 := arg2
+	C._wrap_Segment_way_id_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_36(_swig_i_1))
 }
 
-func (arg1 SwigcptrProfile) GetAllowedWayIds() (_swig_ret IntVector) {
-	var swig_r IntVector
+func (arg1 SwigcptrSegment) GetWay_id() (_swig_ret int64) {
+	var swig_r int64
 	_swig_i_0 := arg1
-	swig_r = (IntVector)(SwigcptrIntVector(C._wrap_Profile_allowedWayIds_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (int64)(C._wrap_Segment_way_id_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrProfile) SetWaySpeeds(arg2 Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_) {
+func (arg1 SwigcptrSegment) SetName(arg2 string) {
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_Profile_waySpeeds_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	_swig_i_1 := arg2
+	C._wrap_Segment_name_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), *(*C.swig_type_38)(unsafe.Pointer(&_swig_i_1)))
+	if Swig_escape_always_false {
+		Swig_escape_val = arg2
+	}
 }
 
-func (arg1 SwigcptrProfile) GetWaySpeeds() (_swig_ret Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_) {
-	var swig_r Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_
+func (arg1 SwigcptrSegment) GetName() (_swig_ret string) {
+	var swig_r string
 	_swig_i_0 := arg1
-	swig_r = (Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_)(SwigcptrStd_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_(C._wrap_Profile_waySpeeds_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
-	return swig_r
+	swig_r_p := C._wrap_Segment_name_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
+	var swig_r_1 string
+ swig_r_1 = swigCopyString(swig_r) 
+	return swig_r_1
 }
 
-func (arg1 SwigcptrProfile) SetTransportMode(arg2 Transport_mode) {
+func (arg1 SwigcptrSegment) SetRef(arg2 string) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_Profile_transportMode_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+	C._wrap_Segment_ref_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), *(*C.swig_type_40)(unsafe.Pointer(&_swig_i_1)))
+	if Swig_escape_always_false {
+		Swig_escape_val = arg2
+	}
 }
 
-func (arg1 SwigcptrProfile) GetTransportMode() (_swig_ret Transport_mode) {
-	var swig_r Transport_mode
+func (arg1 SwigcptrSegment) GetRef() (_swig_ret string) {
+	var swig_r string
 	_swig_i_0 := arg1
-	swig_r = (Transport_mode)(C._wrap_Profile_transportMode_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
-	return swig_r
+	swig_r_p := C._wrap_Segment_ref_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
+	var swig_r_1 string
+ swig_r_1 = swigCopyString(swig_r) 
+	return swig_r_1
 }
 
-func (arg1 SwigcptrProfile) SetName(arg2 string) {
+func (arg1 SwigcptrSegment) SetHighway(arg2 string) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_Profile_name_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), *(*C.swig_type_28)(unsafe.Pointer(&_swig_i_1)))
+	C._wrap_Segment_highway_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), *(*C.swig_type_42)(unsafe.Pointer(&_swig_i_1)))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 }
 
-func (arg1 SwigcptrProfile) GetName() (_swig_ret string) {
+func (arg1 SwigcptrSegment) GetHighway() (_swig_ret string) {
 	var swig_r string
 	_swig_i_0 := arg1
-	swig_r_p := C._wrap_Profile_name_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r_p := C._wrap_Segment_highway_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
 	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
 	var swig_r_1 string
  swig_r_1 = swigCopyString(swig_r) 
 	return swig_r_1
 }
 
-func (arg1 SwigcptrProfile) SetPrevent_left_turns(arg2 bool) {
+func (arg1 SwigcptrSegment) SetDistance(arg2 uint) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_Profile_prevent_left_turns_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C._Bool(_swig_i_1))
+	C._wrap_Segment_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrProfile) GetPrevent_left_turns() (_swig_ret bool) {
-	var swig_r bool
+func (arg1 SwigcptrSegment) GetDistance() (_swig_ret uint) {
+	var swig_r uint
 	_swig_i_0 := arg1
-	swig_r = (bool)(C._wrap_Profile_prevent_left_turns_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	swig_r = (uint)(C._wrap_Segment_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrProfile) SetPrevent_u_turns(arg2 bool) {
+func (arg1 SwigcptrSegment) SetDuration(arg2 uint) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_Profile_prevent_u_turns_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C._Bool(_swig_i_1))
+	C._wrap_Segment_duration_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrProfile) GetPrevent_u_turns() (_swig_ret bool) {
-	var swig_r bool
+func (arg1 SwigcptrSegment) GetDuration() (_swig_ret uint) {
+	var swig_r uint
 	_swig_i_0 := arg1
-	swig_r = (bool)(C._wrap_Profile_prevent_u_turns_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	swig_r = (uint)(C._wrap_Segment_duration_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegment) SetSpeed(arg2 uint) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Segment_speed_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegment) GetSpeed() (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	swig_r = (uint)(C._wrap_Segment_speed_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegment) SetFrom(arg2 Point) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_Segment_from_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegment) GetFrom() (_swig_ret Point) {
+	var swig_r Point
+	_swig_i_0 := arg1
+	swig_r = (Point)(SwigcptrPoint(C._wrap_Segment_from_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegment) SetTo(arg2 Point) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_Segment_to_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegment) GetTo() (_swig_ret Point) {
+	var swig_r Point
+	_swig_i_0 := arg1
+	swig_r = (Point)(SwigcptrPoint(C._wrap_Segment_to_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegment) SetStart_bearing(arg2 float32) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Segment_start_bearing_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.float(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegment) GetStart_bearing() (_swig_ret float32) {
+	var swig_r float32
+	_swig_i_0 := arg1
+	swig_r = (float32)(C._wrap_Segment_start_bearing_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSegment) SetEnd_bearing(arg2 float32) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Segment_end_bearing_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.float(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegment) GetEnd_bearing() (_swig_ret float32) {
+	var swig_r float32
+	_swig_i_0 := arg1
+	swig_r = (float32)(C._wrap_Segment_end_bearing_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrProfile) SetTravel_time(arg2 bool) {
+func (arg1 SwigcptrSegment) SetRoundabout(arg2 bool) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_Profile_travel_time_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C._Bool(_swig_i_1))
+	C._wrap_Segment_roundabout_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C._Bool(_swig_i_1))
 }
 
-func (arg1 SwigcptrProfile) GetTravel_time() (_swig_ret bool) {
+func (arg1 SwigcptrSegment) GetRoundabout() (_swig_ret bool) {
 	var swig_r bool
 	_swig_i_0 := arg1
-	swig_r = (bool)(C._wrap_Profile_travel_time_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	swig_r = (bool)(C._wrap_Segment_roundabout_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func NewProfile() (_swig_ret Profile) {
-	var swig_r Profile
-	swig_r = (Profile)(SwigcptrProfile(C._wrap_new_Profile_routingkit_75139fcf52884c4c()))
+func (arg1 SwigcptrSegment) SetBranches(arg2 uint) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Segment_branches_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+}
+
+func (arg1 SwigcptrSegment) GetBranches() (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	swig_r = (uint)(C._wrap_Segment_branches_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func DeleteProfile(arg1 Profile) {
+func NewSegment() (_swig_ret Segment) {
+	var swig_r Segment
+	swig_r = (Segment)(SwigcptrSegment(C._wrap_new_Segment_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteSegment(arg1 Segment) {
 	_swig_i_0 := arg1.Swigcptr()
-	C._wrap_delete_Profile_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	C._wrap_delete_Segment_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
 }
 
-type Profile interface {
+type Segment interface {
 	Swigcptr() uintptr
-	SwigIsProfile()
-	SetAllowedWayIds(arg2 IntVector)
-	GetAllowedWayIds() (_swig_ret IntVector)
-	SetWaySpeeds(arg2 Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_)
-	GetWaySpeeds() (_swig_ret Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_)
-	SetTransportMode(arg2 Transport_mode)
-	GetTransportMode() (_swig_ret Transport_mode)
+	SwigIsSegment()
+	SetWay_id(arg2 int64)
+	GetWay_id() (_swig_ret int64)
 	SetName(arg2 string)
 	GetName() (_swig_ret string)
-	SetPrevent_left_turns(arg2 bool)
-	GetPrevent_left_turns() (_swig_ret bool)
-	SetPrevent_u_turns(arg2 bool)
-	GetPrevent_u_turns() (_swig_ret bool)
-	SetTravel_time(arg2 bool)
-	GetTravel_time() (_swig_ret bool)
-}
-
-func GetMax_distance() (_swig_ret uint) {
-	var swig_r uint
-	swig_r = (uint)(C._wrap_max_distance_get_routingkit_75139fcf52884c4c())
-	return swig_r
+	SetRef(arg2 string)
+	GetRef() (_swig_ret string)
+	SetHighway(arg2 string)
+	GetHighway() (_swig_ret string)
+	SetDistance(arg2 uint)
+	GetDistance() (_swig_ret uint)
+	SetDuration(arg2 uint)
+	GetDuration() (_swig_ret uint)
+	SetSpeed(arg2 uint)
+	GetSpeed() (_swig_ret uint)
+	SetFrom(arg2 Point)
+	GetFrom() (_swig_ret Point)
+	SetTo(arg2 Point)
+	GetTo() (_swig_ret Point)
+	SetStart_bearing(arg2 float32)
+	GetStart_bearing() (_swig_ret float32)
+	SetEnd_bearing(arg2 float32)
+	GetEnd_bearing() (_swig_ret float32)
+	SetRoundabout(arg2 bool)
+	GetRoundabout() (_swig_ret bool)
+	SetBranches(arg2 uint)
+	GetBranches() (_swig_ret uint)
 }
 
-type SwigcptrRoutingGraph uintptr
+type SwigcptrQueryResponse uintptr
 
-func (p SwigcptrRoutingGraph) Swigcptr() uintptr {
+func (p SwigcptrQueryResponse) Swigcptr() uintptr {
 	return (uintptr)(p)
 }
 
-func (p SwigcptrRoutingGraph) SwigIsRoutingGraph() {
+func (p SwigcptrQueryResponse) SwigIsQueryResponse() {
 }
 
-func (arg1 SwigcptrRoutingGraph) SetFirst_out(arg2 UnsignedVector) {
+func (arg1 SwigcptrQueryResponse) SetStatus(arg2 Query_status) {
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_first_out_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	_swig_i_1 := arg2
+	C._wrap_QueryResponse_status_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetFirst_out() (_swig_ret UnsignedVector) {
-	var swig_r UnsignedVector
+func (arg1 SwigcptrQueryResponse) GetStatus() (_swig_ret Query_status) {
+	var swig_r Query_status
 	_swig_i_0 := arg1
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_first_out_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (Query_status)(C._wrap_QueryResponse_status_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) SetHead(arg2 UnsignedVector) {
+func (arg1 SwigcptrQueryResponse) SetDistance(arg2 uint) {
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_head_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	_swig_i_1 := arg2
+	C._wrap_QueryResponse_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetHead() (_swig_ret UnsignedVector) {
-	var swig_r UnsignedVector
+func (arg1 SwigcptrQueryResponse) GetDistance() (_swig_ret uint) {
+	var swig_r uint
 	_swig_i_0 := arg1
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_head_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (uint)(C._wrap_QueryResponse_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) SetTravel_time(arg2 UnsignedVector) {
+func (arg1 SwigcptrQueryResponse) SetGeo_distance(arg2 uint) {
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_travel_time_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	_swig_i_1 := arg2
+	C._wrap_QueryResponse_geo_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetTravel_time() (_swig_ret UnsignedVector) {
-	var swig_r UnsignedVector
+func (arg1 SwigcptrQueryResponse) GetGeo_distance() (_swig_ret uint) {
+	var swig_r uint
 	_swig_i_0 := arg1
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_travel_time_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (uint)(C._wrap_QueryResponse_geo_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) SetGeo_distance(arg2 UnsignedVector) {
+func (arg1 SwigcptrQueryResponse) SetTravel_time(arg2 uint) {
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_geo_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	_swig_i_1 := arg2
+	C._wrap_QueryResponse_travel_time_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetGeo_distance() (_swig_ret UnsignedVector) {
-	var swig_r UnsignedVector
+func (arg1 SwigcptrQueryResponse) GetTravel_time() (_swig_ret uint) {
+	var swig_r uint
 	_swig_i_0 := arg1
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_geo_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (uint)(C._wrap_QueryResponse_travel_time_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) SetLatitude(arg2 FloatVector) {
+func (arg1 SwigcptrQueryResponse) SetWaypoints(arg2 PointVector) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_latitude_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	C._wrap_QueryResponse_waypoints_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetLatitude() (_swig_ret FloatVector) {
-	var swig_r FloatVector
+func (arg1 SwigcptrQueryResponse) GetWaypoints() (_swig_ret PointVector) {
+	var swig_r PointVector
 	_swig_i_0 := arg1
-	swig_r = (FloatVector)(SwigcptrFloatVector(C._wrap_RoutingGraph_latitude_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (PointVector)(SwigcptrPointVector(C._wrap_QueryResponse_waypoints_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) SetLongitude(arg2 FloatVector) {
+func (arg1 SwigcptrQueryResponse) SetSource(arg2 Point) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_longitude_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	C._wrap_QueryResponse_source_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetLongitude() (_swig_ret FloatVector) {
-	var swig_r FloatVector
+func (arg1 SwigcptrQueryResponse) GetSource() (_swig_ret Point) {
+	var swig_r Point
 	_swig_i_0 := arg1
-	swig_r = (FloatVector)(SwigcptrFloatVector(C._wrap_RoutingGraph_longitude_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (Point)(SwigcptrPoint(C._wrap_QueryResponse_source_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) SetForbidden_turn_from_arc(arg2 UnsignedVector) {
+func (arg1 SwigcptrQueryResponse) SetTarget(arg2 Point) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_forbidden_turn_from_arc_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	C._wrap_QueryResponse_target_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetForbidden_turn_from_arc() (_swig_ret UnsignedVector) {
-	var swig_r UnsignedVector
+func (arg1 SwigcptrQueryResponse) GetTarget() (_swig_ret Point) {
+	var swig_r Point
 	_swig_i_0 := arg1
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_forbidden_turn_from_arc_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (Point)(SwigcptrPoint(C._wrap_QueryResponse_target_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) SetForbidden_turn_to_arc(arg2 UnsignedVector) {
+func (arg1 SwigcptrQueryResponse) SetSegments(arg2 SegmentVector) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_RoutingGraph_forbidden_turn_to_arc_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	C._wrap_QueryResponse_segments_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
 }
 
-func (arg1 SwigcptrRoutingGraph) GetForbidden_turn_to_arc() (_swig_ret UnsignedVector) {
-	var swig_r UnsignedVector
+func (arg1 SwigcptrQueryResponse) GetSegments() (_swig_ret SegmentVector) {
+	var swig_r SegmentVector
 	_swig_i_0 := arg1
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_forbidden_turn_to_arc_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	swig_r = (SegmentVector)(SwigcptrSegmentVector(C._wrap_QueryResponse_segments_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) Node_count() (_swig_ret uint) {
+func (arg1 SwigcptrQueryResponse) SetLast_arc(arg2 uint) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_QueryResponse_last_arc_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+}
+
+func (arg1 SwigcptrQueryResponse) GetLast_arc() (_swig_ret uint) {
 	var swig_r uint
 	_swig_i_0 := arg1
-	swig_r = (uint)(C._wrap_RoutingGraph_node_count_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	swig_r = (uint)(C._wrap_QueryResponse_last_arc_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrRoutingGraph) Arc_count() (_swig_ret uint) {
-	var swig_r uint
+func (arg1 SwigcptrQueryResponse) SetLast_fraction(arg2 float32) {
 	_swig_i_0 := arg1
-	swig_r = (uint)(C._wrap_RoutingGraph_arc_count_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	_swig_i_1 := arg2
+	C._wrap_QueryResponse_last_fraction_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.float(_swig_i_1))
+}
+
+func (arg1 SwigcptrQueryResponse) GetLast_fraction() (_swig_ret float32) {
+	var swig_r float32
+	_swig_i_0 := arg1
+	swig_r = (float32)(C._wrap_QueryResponse_last_fraction_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func NewRoutingGraph() (_swig_ret RoutingGraph) {
-	var swig_r RoutingGraph
-	swig_r = (RoutingGraph)(SwigcptrRoutingGraph(C._wrap_new_RoutingGraph_routingkit_75139fcf52884c4c()))
+func NewQueryResponse() (_swig_ret QueryResponse) {
+	var swig_r QueryResponse
+	swig_r = (QueryResponse)(SwigcptrQueryResponse(C._wrap_new_QueryResponse_routingkit_75139fcf52884c4c()))
 	return swig_r
 }
 
-func DeleteRoutingGraph(arg1 RoutingGraph) {
+func DeleteQueryResponse(arg1 QueryResponse) {
 	_swig_i_0 := arg1.Swigcptr()
-	C._wrap_delete_RoutingGraph_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	C._wrap_delete_QueryResponse_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
 }
 
-type RoutingGraph interface {
+type QueryResponse interface {
 	Swigcptr() uintptr
-	SwigIsRoutingGraph()
-	SetFirst_out(arg2 UnsignedVector)
-	GetFirst_out() (_swig_ret UnsignedVector)
-	SetHead(arg2 UnsignedVector)
-	GetHead() (_swig_ret UnsignedVector)
-	SetTravel_time(arg2 UnsignedVector)
-	GetTravel_time() (_swig_ret UnsignedVector)
-	SetGeo_distance(arg2 UnsignedVector)
-	GetGeo_distance() (_swig_ret UnsignedVector)
-	SetLatitude(arg2 FloatVector)
-	GetLatitude() (_swig_ret FloatVector)
-	SetLongitude(arg2 FloatVector)
-	GetLongitude() (_swig_ret FloatVector)
-	SetForbidden_turn_from_arc(arg2 UnsignedVector)
-	GetForbidden_turn_from_arc() (_swig_ret UnsignedVector)
-	SetForbidden_turn_to_arc(arg2 UnsignedVector)
-	GetForbidden_turn_to_arc() (_swig_ret UnsignedVector)
-	Node_count() (_swig_ret uint)
-	Arc_count() (_swig_ret uint)
+	SwigIsQueryResponse()
+	SetStatus(arg2 Query_status)
+	GetStatus() (_swig_ret Query_status)
+	SetDistance(arg2 uint)
+	GetDistance() (_swig_ret uint)
+	SetGeo_distance(arg2 uint)
+	GetGeo_distance() (_swig_ret uint)
+	SetTravel_time(arg2 uint)
+	GetTravel_time() (_swig_ret uint)
+	SetWaypoints(arg2 PointVector)
+	GetWaypoints() (_swig_ret PointVector)
+	SetSource(arg2 Point)
+	GetSource() (_swig_ret Point)
+	SetTarget(arg2 Point)
+	GetTarget() (_swig_ret Point)
+	SetSegments(arg2 SegmentVector)
+	GetSegments() (_swig_ret SegmentVector)
+	SetLast_arc(arg2 uint)
+	GetLast_arc() (_swig_ret uint)
+	SetLast_fraction(arg2 float32)
+	GetLast_fraction() (_swig_ret float32)
 }
 
-type SwigcptrClient uintptr
+type SwigcptrSnapResponse uintptr
 
-func (p SwigcptrClient) Swigcptr() uintptr {
+func (p SwigcptrSnapResponse) Swigcptr() uintptr {
 	return (uintptr)(p)
 }
 
-func (p SwigcptrClient) SwigIsClient() {
+func (p SwigcptrSnapResponse) SwigIsSnapResponse() {
 }
 
-func (arg1 SwigcptrClient) Query(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 float32, arg7 float32, arg8 bool) (_swig_ret QueryResponse) {
-	var swig_r QueryResponse
+func (arg1 SwigcptrSnapResponse) SetSnapped(arg2 bool) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	_swig_i_2 := arg3
-	_swig_i_3 := arg4
-	_swig_i_4 := arg5
-	_swig_i_5 := arg6
-	_swig_i_6 := arg7
-	_swig_i_7 := arg8
-	swig_r = (QueryResponse)(SwigcptrQueryResponse(C._wrap_Client_query_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4), C.float(_swig_i_5), C.float(_swig_i_6), C._Bool(_swig_i_7))))
-	return swig_r
+	C._wrap_SnapResponse_snapped_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C._Bool(_swig_i_1))
 }
 
-func (arg1 SwigcptrClient) Distances(arg2 int, arg3 float32, arg4 Point, arg5 PointVector) (_swig_ret UnsignedVector) {
-	var swig_r UnsignedVector
+func (arg1 SwigcptrSnapResponse) GetSnapped() (_swig_ret bool) {
+	var swig_r bool
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2
-	_swig_i_2 := arg3
-	_swig_i_3 := arg4.Swigcptr()
-	_swig_i_4 := arg5.Swigcptr()
-	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_Client_distances_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.uintptr_t(_swig_i_3), C.uintptr_t(_swig_i_4))))
+	swig_r = (bool)(C._wrap_SnapResponse_snapped_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrClient) Nearest(arg2 int, arg3 float32, arg4 float32, arg5 float32) (_swig_ret Point) {
+func (arg1 SwigcptrSnapResponse) SetPoint(arg2 Point) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_SnapResponse_point_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrSnapResponse) GetPoint() (_swig_ret Point) {
 	var swig_r Point
 	_swig_i_0 := arg1
-	_swig_i_1 := arg2
-	_swig_i_2 := arg3
-	_swig_i_3 := arg4
-	_swig_i_4 := arg5
-	swig_r = (Point)(SwigcptrPoint(C._wrap_Client_nearest_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4))))
+	swig_r = (Point)(SwigcptrPoint(C._wrap_SnapResponse_point_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
 	return swig_r
 }
 
-func NewClient(arg1 int, arg2 string, arg3 string, arg4 Profile) (_swig_ret Client) {
-	var swig_r Client
+func (arg1 SwigcptrSnapResponse) SetDistance(arg2 float32) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	_swig_i_2 := arg3
-	_swig_i_3 := arg4.Swigcptr()
-	swig_r = (Client)(SwigcptrClient(C._wrap_new_Client_routingkit_75139fcf52884c4c(C.swig_intgo(_swig_i_0), *(*C.swig_type_30)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_31)(unsafe.Pointer(&_swig_i_2)), C.uintptr_t(_swig_i_3))))
-	if Swig_escape_always_false {
-		Swig_escape_val = arg2
-	}
-	if Swig_escape_always_false {
-		Swig_escape_val = arg3
-	}
-	return swig_r
+	C._wrap_SnapResponse_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.float(_swig_i_1))
+}
+
+func (arg1 SwigcptrSnapResponse) GetDistance() (_swig_ret float32) {
+	var swig_r float32
+	_swig_i_0 := arg1
+	swig_r = (float32)(C._wrap_SnapResponse_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSnapResponse) SetWay_id(arg2 int64) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_SnapResponse_way_id_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_type_44(_swig_i_1))
+}
+
+func (arg1 SwigcptrSnapResponse) GetWay_id() (_swig_ret int64) {
+	var swig_r int64
+	_swig_i_0 := arg1
+	swig_r = (int64)(C._wrap_SnapResponse_way_id_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrSnapResponse) SetName(arg2 string) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_SnapResponse_name_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), *(*C.swig_type_46)(unsafe.Pointer(&_swig_i_1)))
+	if Swig_escape_always_false {
+		Swig_escape_val = arg2
+	}
+}
+
+func (arg1 SwigcptrSnapResponse) GetName() (_swig_ret string) {
+	var swig_r string
+	_swig_i_0 := arg1
+	swig_r_p := C._wrap_SnapResponse_name_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
+	var swig_r_1 string
+ swig_r_1 = swigCopyString(swig_r) 
+	return swig_r_1
+}
+
+func (arg1 SwigcptrSnapResponse) SetHighway(arg2 string) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_SnapResponse_highway_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), *(*C.swig_type_48)(unsafe.Pointer(&_swig_i_1)))
+	if Swig_escape_always_false {
+		Swig_escape_val = arg2
+	}
+}
+
+func (arg1 SwigcptrSnapResponse) GetHighway() (_swig_ret string) {
+	var swig_r string
+	_swig_i_0 := arg1
+	swig_r_p := C._wrap_SnapResponse_highway_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
+	var swig_r_1 string
+ swig_r_1 = swigCopyString(swig_r) 
+	return swig_r_1
+}
+
+func NewSnapResponse() (_swig_ret SnapResponse) {
+	var swig_r SnapResponse
+	swig_r = (SnapResponse)(SwigcptrSnapResponse(C._wrap_new_SnapResponse_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteSnapResponse(arg1 SnapResponse) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_SnapResponse_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type SnapResponse interface {
+	Swigcptr() uintptr
+	SwigIsSnapResponse()
+	SetSnapped(arg2 bool)
+	GetSnapped() (_swig_ret bool)
+	SetPoint(arg2 Point)
+	GetPoint() (_swig_ret Point)
+	SetDistance(arg2 float32)
+	GetDistance() (_swig_ret float32)
+	SetWay_id(arg2 int64)
+	GetWay_id() (_swig_ret int64)
+	SetName(arg2 string)
+	GetName() (_swig_ret string)
+	SetHighway(arg2 string)
+	GetHighway() (_swig_ret string)
+}
+
+type SwigcptrDistancesResponse uintptr
+
+func (p SwigcptrDistancesResponse) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrDistancesResponse) SwigIsDistancesResponse() {
+}
+
+func (arg1 SwigcptrDistancesResponse) SetDistances(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_DistancesResponse_distances_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrDistancesResponse) GetDistances() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_DistancesResponse_distances_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrDistancesResponse) SetStatuses(arg2 IntVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_DistancesResponse_statuses_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrDistancesResponse) GetStatuses() (_swig_ret IntVector) {
+	var swig_r IntVector
+	_swig_i_0 := arg1
+	swig_r = (IntVector)(SwigcptrIntVector(C._wrap_DistancesResponse_statuses_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrDistancesResponse) SetGeo_distances(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_DistancesResponse_geo_distances_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrDistancesResponse) GetGeo_distances() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_DistancesResponse_geo_distances_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrDistancesResponse) SetTravel_times(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_DistancesResponse_travel_times_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrDistancesResponse) GetTravel_times() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_DistancesResponse_travel_times_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func NewDistancesResponse() (_swig_ret DistancesResponse) {
+	var swig_r DistancesResponse
+	swig_r = (DistancesResponse)(SwigcptrDistancesResponse(C._wrap_new_DistancesResponse_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteDistancesResponse(arg1 DistancesResponse) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_DistancesResponse_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type DistancesResponse interface {
+	Swigcptr() uintptr
+	SwigIsDistancesResponse()
+	SetDistances(arg2 UnsignedVector)
+	GetDistances() (_swig_ret UnsignedVector)
+	SetStatuses(arg2 IntVector)
+	GetStatuses() (_swig_ret IntVector)
+	SetGeo_distances(arg2 UnsignedVector)
+	GetGeo_distances() (_swig_ret UnsignedVector)
+	SetTravel_times(arg2 UnsignedVector)
+	GetTravel_times() (_swig_ret UnsignedVector)
+}
+
+type SwigcptrReachResponse uintptr
+
+func (p SwigcptrReachResponse) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrReachResponse) SwigIsReachResponse() {
+}
+
+func (arg1 SwigcptrReachResponse) SetPoints(arg2 PointVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_ReachResponse_points_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrReachResponse) GetPoints() (_swig_ret PointVector) {
+	var swig_r PointVector
+	_swig_i_0 := arg1
+	swig_r = (PointVector)(SwigcptrPointVector(C._wrap_ReachResponse_points_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrReachResponse) SetCosts(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_ReachResponse_costs_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrReachResponse) GetCosts() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_ReachResponse_costs_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func NewReachResponse() (_swig_ret ReachResponse) {
+	var swig_r ReachResponse
+	swig_r = (ReachResponse)(SwigcptrReachResponse(C._wrap_new_ReachResponse_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteReachResponse(arg1 ReachResponse) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_ReachResponse_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type ReachResponse interface {
+	Swigcptr() uintptr
+	SwigIsReachResponse()
+	SetPoints(arg2 PointVector)
+	GetPoints() (_swig_ret PointVector)
+	SetCosts(arg2 UnsignedVector)
+	GetCosts() (_swig_ret UnsignedVector)
+}
+
+type SwigcptrMatchResponse uintptr
+
+func (p SwigcptrMatchResponse) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrMatchResponse) SwigIsMatchResponse() {
+}
+
+func (arg1 SwigcptrMatchResponse) SetPoints(arg2 PointVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_MatchResponse_points_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrMatchResponse) GetPoints() (_swig_ret PointVector) {
+	var swig_r PointVector
+	_swig_i_0 := arg1
+	swig_r = (PointVector)(SwigcptrPointVector(C._wrap_MatchResponse_points_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrMatchResponse) SetMatched(arg2 IntVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_MatchResponse_matched_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrMatchResponse) GetMatched() (_swig_ret IntVector) {
+	var swig_r IntVector
+	_swig_i_0 := arg1
+	swig_r = (IntVector)(SwigcptrIntVector(C._wrap_MatchResponse_matched_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrMatchResponse) SetConfidences(arg2 FloatVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_MatchResponse_confidences_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrMatchResponse) GetConfidences() (_swig_ret FloatVector) {
+	var swig_r FloatVector
+	_swig_i_0 := arg1
+	swig_r = (FloatVector)(SwigcptrFloatVector(C._wrap_MatchResponse_confidences_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrMatchResponse) SetPath(arg2 PointVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_MatchResponse_path_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrMatchResponse) GetPath() (_swig_ret PointVector) {
+	var swig_r PointVector
+	_swig_i_0 := arg1
+	swig_r = (PointVector)(SwigcptrPointVector(C._wrap_MatchResponse_path_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrMatchResponse) SetDistance(arg2 uint) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_MatchResponse_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+}
+
+func (arg1 SwigcptrMatchResponse) GetDistance() (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	swig_r = (uint)(C._wrap_MatchResponse_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func NewMatchResponse() (_swig_ret MatchResponse) {
+	var swig_r MatchResponse
+	swig_r = (MatchResponse)(SwigcptrMatchResponse(C._wrap_new_MatchResponse_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteMatchResponse(arg1 MatchResponse) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_MatchResponse_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type MatchResponse interface {
+	Swigcptr() uintptr
+	SwigIsMatchResponse()
+	SetPoints(arg2 PointVector)
+	GetPoints() (_swig_ret PointVector)
+	SetMatched(arg2 IntVector)
+	GetMatched() (_swig_ret IntVector)
+	SetConfidences(arg2 FloatVector)
+	GetConfidences() (_swig_ret FloatVector)
+	SetPath(arg2 PointVector)
+	GetPath() (_swig_ret PointVector)
+	SetDistance(arg2 uint)
+	GetDistance() (_swig_ret uint)
+}
+
+type Transport_mode int
+func _swig_getvehicle() (_swig_ret Transport_mode) {
+	var swig_r Transport_mode
+	swig_r = (Transport_mode)(C._wrap_vehicle_routingkit_75139fcf52884c4c())
+	return swig_r
+}
+
+var Vehicle Transport_mode = _swig_getvehicle()
+func _swig_getbike() (_swig_ret Transport_mode) {
+	var swig_r Transport_mode
+	swig_r = (Transport_mode)(C._wrap_bike_routingkit_75139fcf52884c4c())
+	return swig_r
+}
+
+var Bike Transport_mode = _swig_getbike()
+func _swig_getpedestrian() (_swig_ret Transport_mode) {
+	var swig_r Transport_mode
+	swig_r = (Transport_mode)(C._wrap_pedestrian_routingkit_75139fcf52884c4c())
+	return swig_r
+}
+
+var Pedestrian Transport_mode = _swig_getpedestrian()
+type SwigcptrProfile uintptr
+
+func (p SwigcptrProfile) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrProfile) SwigIsProfile() {
+}
+
+func (arg1 SwigcptrProfile) SetAllowedWayIds(arg2 IntVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_Profile_allowedWayIds_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrProfile) GetAllowedWayIds() (_swig_ret IntVector) {
+	var swig_r IntVector
+	_swig_i_0 := arg1
+	swig_r = (IntVector)(SwigcptrIntVector(C._wrap_Profile_allowedWayIds_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrProfile) SetWaySpeeds(arg2 Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_Profile_waySpeeds_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrProfile) GetWaySpeeds() (_swig_ret Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_) {
+	var swig_r Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_
+	_swig_i_0 := arg1
+	swig_r = (Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_)(SwigcptrStd_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_(C._wrap_Profile_waySpeeds_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrProfile) SetTransportMode(arg2 Transport_mode) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Profile_transportMode_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+}
+
+func (arg1 SwigcptrProfile) GetTransportMode() (_swig_ret Transport_mode) {
+	var swig_r Transport_mode
+	_swig_i_0 := arg1
+	swig_r = (Transport_mode)(C._wrap_Profile_transportMode_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrProfile) SetName(arg2 string) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Profile_name_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), *(*C.swig_type_50)(unsafe.Pointer(&_swig_i_1)))
+	if Swig_escape_always_false {
+		Swig_escape_val = arg2
+	}
+}
+
+func (arg1 SwigcptrProfile) GetName() (_swig_ret string) {
+	var swig_r string
+	_swig_i_0 := arg1
+	swig_r_p := C._wrap_Profile_name_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
+	var swig_r_1 string
+ swig_r_1 = swigCopyString(swig_r) 
+	return swig_r_1
+}
+
+func (arg1 SwigcptrProfile) SetPrevent_left_turns(arg2 bool) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Profile_prevent_left_turns_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C._Bool(_swig_i_1))
+}
+
+func (arg1 SwigcptrProfile) GetPrevent_left_turns() (_swig_ret bool) {
+	var swig_r bool
+	_swig_i_0 := arg1
+	swig_r = (bool)(C._wrap_Profile_prevent_left_turns_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrProfile) SetPrevent_u_turns(arg2 bool) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	C._wrap_Profile_prevent_u_turns_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C._Bool(_swig_i_1))
+}
+
+func (arg1 SwigcptrProfile) GetPrevent_u_turns() (_swig_ret bool) {
+	var swig_r bool
+	_swig_i_0 := arg1
+	swig_r = (bool)(C._wrap_Profile_prevent_u_turns_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func NewProfile() (_swig_ret Profile) {
+	var swig_r Profile
+	swig_r = (Profile)(SwigcptrProfile(C._wrap_new_Profile_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteProfile(arg1 Profile) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_Profile_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type Profile interface {
+	Swigcptr() uintptr
+	SwigIsProfile()
+	SetAllowedWayIds(arg2 IntVector)
+	GetAllowedWayIds() (_swig_ret IntVector)
+	SetWaySpeeds(arg2 Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_)
+	GetWaySpeeds() (_swig_ret Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_)
+	SetTransportMode(arg2 Transport_mode)
+	GetTransportMode() (_swig_ret Transport_mode)
+	SetName(arg2 string)
+	GetName() (_swig_ret string)
+	SetPrevent_left_turns(arg2 bool)
+	GetPrevent_left_turns() (_swig_ret bool)
+	SetPrevent_u_turns(arg2 bool)
+	GetPrevent_u_turns() (_swig_ret bool)
+}
+
+func GetMax_distance() (_swig_ret uint) {
+	var swig_r uint
+	swig_r = (uint)(C._wrap_max_distance_get_routingkit_75139fcf52884c4c())
+	return swig_r
+}
+
+func GetInvalid_arc() (_swig_ret uint) {
+	var swig_r uint
+	swig_r = (uint)(C._wrap_invalid_arc_get_routingkit_75139fcf52884c4c())
+	return swig_r
+}
+
+func Install_crash_handlers(arg1 int, arg2 int, arg3 uint64, arg4 uint64) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	C._wrap_install_crash_handlers_routingkit_75139fcf52884c4c(C.swig_intgo(_swig_i_0), C.swig_intgo(_swig_i_1), C.swig_type_52(_swig_i_2), C.swig_type_53(_swig_i_3))
+}
+
+type SwigcptrRoutingGraph uintptr
+
+func (p SwigcptrRoutingGraph) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrRoutingGraph) SwigIsRoutingGraph() {
+}
+
+func (arg1 SwigcptrRoutingGraph) SetFirst_out(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_first_out_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetFirst_out() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_first_out_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetHead(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_head_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetHead() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_head_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetTravel_time(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_travel_time_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetTravel_time() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_travel_time_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetGeo_distance(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_geo_distance_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetGeo_distance() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_geo_distance_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetLatitude(arg2 FloatVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_latitude_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetLatitude() (_swig_ret FloatVector) {
+	var swig_r FloatVector
+	_swig_i_0 := arg1
+	swig_r = (FloatVector)(SwigcptrFloatVector(C._wrap_RoutingGraph_latitude_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetLongitude(arg2 FloatVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_longitude_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetLongitude() (_swig_ret FloatVector) {
+	var swig_r FloatVector
+	_swig_i_0 := arg1
+	swig_r = (FloatVector)(SwigcptrFloatVector(C._wrap_RoutingGraph_longitude_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetForbidden_turn_from_arc(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_forbidden_turn_from_arc_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetForbidden_turn_from_arc() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_forbidden_turn_from_arc_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetForbidden_turn_to_arc(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_forbidden_turn_to_arc_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetForbidden_turn_to_arc() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_forbidden_turn_to_arc_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetWay(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_way_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetWay() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_way_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetWay_speed(arg2 UnsignedVector) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_way_speed_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetWay_speed() (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_RoutingGraph_way_speed_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetFirst_modelling_byte(arg2 SWIGTYPE_p_std__vectorT_unsigned_long_long_t) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_first_modelling_byte_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetFirst_modelling_byte() (_swig_ret SWIGTYPE_p_std__vectorT_unsigned_long_long_t) {
+	var swig_r SWIGTYPE_p_std__vectorT_unsigned_long_long_t
+	_swig_i_0 := arg1
+	swig_r = (SWIGTYPE_p_std__vectorT_unsigned_long_long_t)(SwigcptrSWIGTYPE_p_std__vectorT_unsigned_long_long_t(C._wrap_RoutingGraph_first_modelling_byte_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) SetModelling_bytes(arg2 SWIGTYPE_p_std__vectorT_unsigned_char_t) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	C._wrap_RoutingGraph_modelling_bytes_set_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+}
+
+func (arg1 SwigcptrRoutingGraph) GetModelling_bytes() (_swig_ret SWIGTYPE_p_std__vectorT_unsigned_char_t) {
+	var swig_r SWIGTYPE_p_std__vectorT_unsigned_char_t
+	_swig_i_0 := arg1
+	swig_r = (SWIGTYPE_p_std__vectorT_unsigned_char_t)(SwigcptrSWIGTYPE_p_std__vectorT_unsigned_char_t(C._wrap_RoutingGraph_modelling_bytes_get_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) Node_count() (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	swig_r = (uint)(C._wrap_RoutingGraph_node_count_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrRoutingGraph) Arc_count() (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	swig_r = (uint)(C._wrap_RoutingGraph_arc_count_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func NewRoutingGraph() (_swig_ret RoutingGraph) {
+	var swig_r RoutingGraph
+	swig_r = (RoutingGraph)(SwigcptrRoutingGraph(C._wrap_new_RoutingGraph_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteRoutingGraph(arg1 RoutingGraph) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_RoutingGraph_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type RoutingGraph interface {
+	Swigcptr() uintptr
+	SwigIsRoutingGraph()
+	SetFirst_out(arg2 UnsignedVector)
+	GetFirst_out() (_swig_ret UnsignedVector)
+	SetHead(arg2 UnsignedVector)
+	GetHead() (_swig_ret UnsignedVector)
+	SetTravel_time(arg2 UnsignedVector)
+	GetTravel_time() (_swig_ret UnsignedVector)
+	SetGeo_distance(arg2 UnsignedVector)
+	GetGeo_distance() (_swig_ret UnsignedVector)
+	SetLatitude(arg2 FloatVector)
+	GetLatitude() (_swig_ret FloatVector)
+	SetLongitude(arg2 FloatVector)
+	GetLongitude() (_swig_ret FloatVector)
+	SetForbidden_turn_from_arc(arg2 UnsignedVector)
+	GetForbidden_turn_from_arc() (_swig_ret UnsignedVector)
+	SetForbidden_turn_to_arc(arg2 UnsignedVector)
+	GetForbidden_turn_to_arc() (_swig_ret UnsignedVector)
+	SetWay(arg2 UnsignedVector)
+	GetWay() (_swig_ret UnsignedVector)
+	SetWay_speed(arg2 UnsignedVector)
+	GetWay_speed() (_swig_ret UnsignedVector)
+	SetFirst_modelling_byte(arg2 SWIGTYPE_p_std__vectorT_unsigned_long_long_t)
+	GetFirst_modelling_byte() (_swig_ret SWIGTYPE_p_std__vectorT_unsigned_long_long_t)
+	SetModelling_bytes(arg2 SWIGTYPE_p_std__vectorT_unsigned_char_t)
+	GetModelling_bytes() (_swig_ret SWIGTYPE_p_std__vectorT_unsigned_char_t)
+	Node_count() (_swig_ret uint)
+	Arc_count() (_swig_ret uint)
+}
+
+type SwigcptrRoadNetwork uintptr
+
+func (p SwigcptrRoadNetwork) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrRoadNetwork) SwigIsRoadNetwork() {
+}
+
+func NewRoadNetwork(arg1 string, arg2 Profile, arg3 bool) (_swig_ret RoadNetwork) {
+	var swig_r RoadNetwork
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	_swig_i_2 := arg3
+	swig_r = (RoadNetwork)(SwigcptrRoadNetwork(C._wrap_new_RoadNetwork_routingkit_75139fcf52884c4c(*(*C.swig_type_54)(unsafe.Pointer(&_swig_i_0)), C.uintptr_t(_swig_i_1), C._Bool(_swig_i_2))))
+	if Swig_escape_always_false {
+		Swig_escape_val = arg1
+	}
+	return swig_r
+}
+
+func (arg1 SwigcptrRoadNetwork) Load_error() (_swig_ret string) {
+	var swig_r string
+	_swig_i_0 := arg1
+	swig_r_p := C._wrap_RoadNetwork_load_error_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
+	var swig_r_1 string
+ swig_r_1 = swigCopyString(swig_r) 
+	return swig_r_1
+}
+
+func DeleteRoadNetwork(arg1 RoadNetwork) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_RoadNetwork_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type RoadNetwork interface {
+	Swigcptr() uintptr
+	SwigIsRoadNetwork()
+	Load_error() (_swig_ret string)
+}
+
+type SwigcptrTargetBuckets uintptr
+
+func (p SwigcptrTargetBuckets) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrTargetBuckets) SwigIsTargetBuckets() {
+}
+
+func (arg1 SwigcptrTargetBuckets) Target_count() (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	swig_r = (uint)(C._wrap_TargetBuckets_target_count_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func NewTargetBuckets() (_swig_ret TargetBuckets) {
+	var swig_r TargetBuckets
+	swig_r = (TargetBuckets)(SwigcptrTargetBuckets(C._wrap_new_TargetBuckets_routingkit_75139fcf52884c4c()))
+	return swig_r
+}
+
+func DeleteTargetBuckets(arg1 TargetBuckets) {
+	_swig_i_0 := arg1.Swigcptr()
+	C._wrap_delete_TargetBuckets_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+}
+
+type TargetBuckets interface {
+	Swigcptr() uintptr
+	SwigIsTargetBuckets()
+	Target_count() (_swig_ret uint)
+}
+
+type SwigcptrClient uintptr
+
+func (p SwigcptrClient) Swigcptr() uintptr {
+	return (uintptr)(p)
+}
+
+func (p SwigcptrClient) SwigIsClient() {
+}
+
+func (arg1 SwigcptrClient) Query(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 float32, arg7 float32, arg8 float32, arg9 float32, arg10 float32, arg11 float32, arg12 bool, arg13 bool) (_swig_ret QueryResponse) {
+	var swig_r QueryResponse
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7
+	_swig_i_7 := arg8
+	_swig_i_8 := arg9
+	_swig_i_9 := arg10
+	_swig_i_10 := arg11
+	_swig_i_11 := arg12
+	_swig_i_12 := arg13
+	swig_r = (QueryResponse)(SwigcptrQueryResponse(C._wrap_Client_query_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4), C.float(_swig_i_5), C.float(_swig_i_6), C.float(_swig_i_7), C.float(_swig_i_8), C.float(_swig_i_9), C.float(_swig_i_10), C._Bool(_swig_i_11), C._Bool(_swig_i_12))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Continue_query(arg2 int, arg3 float32, arg4 uint, arg5 float32, arg6 float32, arg7 float32, arg8 bool, arg9 bool) (_swig_ret QueryResponse) {
+	var swig_r QueryResponse
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7
+	_swig_i_7 := arg8
+	_swig_i_8 := arg9
+	swig_r = (QueryResponse)(SwigcptrQueryResponse(C._wrap_Client_continue_query_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.swig_intgo(_swig_i_3), C.float(_swig_i_4), C.float(_swig_i_5), C.float(_swig_i_6), C._Bool(_swig_i_7), C._Bool(_swig_i_8))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Alternatives(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 float32, arg7 float32, arg8 uint, arg9 float32, arg10 float32, arg11 bool, arg12 bool) (_swig_ret QueryResponseVector) {
+	var swig_r QueryResponseVector
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7
+	_swig_i_7 := arg8
+	_swig_i_8 := arg9
+	_swig_i_9 := arg10
+	_swig_i_10 := arg11
+	_swig_i_11 := arg12
+	swig_r = (QueryResponseVector)(SwigcptrQueryResponseVector(C._wrap_Client_alternatives_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4), C.float(_swig_i_5), C.float(_swig_i_6), C.swig_intgo(_swig_i_7), C.float(_swig_i_8), C.float(_swig_i_9), C._Bool(_swig_i_10), C._Bool(_swig_i_11))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Many_to_many(arg2 IntVector, arg3 float32, arg4 PointVector, arg5 PointVector, arg6 SWIGTYPE_p_unsigned_int, arg7 SWIGTYPE_p_int, arg8 SWIGTYPE_p_unsigned_int, arg9 SWIGTYPE_p_unsigned_int, arg10 SWIGTYPE_p_int) (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4.Swigcptr()
+	_swig_i_4 := arg5.Swigcptr()
+	_swig_i_5 := arg6.Swigcptr()
+	_swig_i_6 := arg7.Swigcptr()
+	_swig_i_7 := arg8.Swigcptr()
+	_swig_i_8 := arg9.Swigcptr()
+	_swig_i_9 := arg10.Swigcptr()
+	swig_r = (uint)(C._wrap_Client_many_to_many_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), C.float(_swig_i_2), C.uintptr_t(_swig_i_3), C.uintptr_t(_swig_i_4), C.uintptr_t(_swig_i_5), C.uintptr_t(_swig_i_6), C.uintptr_t(_swig_i_7), C.uintptr_t(_swig_i_8), C.uintptr_t(_swig_i_9)))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Target_buckets(arg2 IntVector, arg3 float32, arg4 PointVector, arg5 uint) (_swig_ret TargetBuckets) {
+	var swig_r TargetBuckets
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4.Swigcptr()
+	_swig_i_4 := arg5
+	swig_r = (TargetBuckets)(SwigcptrTargetBuckets(C._wrap_Client_target_buckets_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), C.float(_swig_i_2), C.uintptr_t(_swig_i_3), C.swig_intgo(_swig_i_4))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Many_to_many_rows(arg2 IntVector, arg3 TargetBuckets, arg4 float32, arg5 PointVector, arg6 SWIGTYPE_p_unsigned_int, arg7 SWIGTYPE_p_int, arg8 SWIGTYPE_p_unsigned_int, arg9 SWIGTYPE_p_unsigned_int, arg10 SWIGTYPE_p_int) (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	_swig_i_2 := arg3.Swigcptr()
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5.Swigcptr()
+	_swig_i_5 := arg6.Swigcptr()
+	_swig_i_6 := arg7.Swigcptr()
+	_swig_i_7 := arg8.Swigcptr()
+	_swig_i_8 := arg9.Swigcptr()
+	_swig_i_9 := arg10.Swigcptr()
+	swig_r = (uint)(C._wrap_Client_many_to_many_rows_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), C.uintptr_t(_swig_i_2), C.float(_swig_i_3), C.uintptr_t(_swig_i_4), C.uintptr_t(_swig_i_5), C.uintptr_t(_swig_i_6), C.uintptr_t(_swig_i_7), C.uintptr_t(_swig_i_8), C.uintptr_t(_swig_i_9)))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Sparse_many_to_many(arg2 IntVector, arg3 TargetBuckets, arg4 float32, arg5 PointVector, arg6 uint, arg7 SWIGTYPE_p_int) (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	_swig_i_2 := arg3.Swigcptr()
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5.Swigcptr()
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7.Swigcptr()
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_Client_sparse_many_to_many_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), C.uintptr_t(_swig_i_2), C.float(_swig_i_3), C.uintptr_t(_swig_i_4), C.swig_intgo(_swig_i_5), C.uintptr_t(_swig_i_6))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Nearest_targets(arg2 int, arg3 TargetBuckets, arg4 float32, arg5 Point, arg6 uint, arg7 uint) (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3.Swigcptr()
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5.Swigcptr()
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_Client_nearest_targets_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.uintptr_t(_swig_i_2), C.float(_swig_i_3), C.uintptr_t(_swig_i_4), C.swig_intgo(_swig_i_5), C.swig_intgo(_swig_i_6))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Node_count() (_swig_ret uint) {
+	var swig_r uint
+	_swig_i_0 := arg1
+	swig_r = (uint)(C._wrap_Client_node_count_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0)))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Node_positions(arg2 SWIGTYPE_p_float, arg3 SWIGTYPE_p_float) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	_swig_i_2 := arg3.Swigcptr()
+	C._wrap_Client_node_positions_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), C.uintptr_t(_swig_i_2))
+}
+
+func (arg1 SwigcptrClient) One_to_all(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 SWIGTYPE_p_unsigned_int, arg7 SWIGTYPE_p_int) (_swig_ret bool) {
+	var swig_r bool
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	_swig_i_5 := arg6.Swigcptr()
+	_swig_i_6 := arg7.Swigcptr()
+	swig_r = (bool)(C._wrap_Client_one_to_all_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4), C.uintptr_t(_swig_i_5), C.uintptr_t(_swig_i_6)))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Sample_costs(arg2 float32, arg3 float32, arg4 float32, arg5 SWIGTYPE_p_unsigned_int, arg6 PointVector, arg7 SWIGTYPE_p_unsigned_int) {
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5.Swigcptr()
+	_swig_i_5 := arg6.Swigcptr()
+	_swig_i_6 := arg7.Swigcptr()
+	C._wrap_Client_sample_costs_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.float(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.uintptr_t(_swig_i_4), C.uintptr_t(_swig_i_5), C.uintptr_t(_swig_i_6))
+}
+
+func (arg1 SwigcptrClient) Reachable(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 uint, arg7 float32, arg8 bool, arg9 SWIGTYPE_p_int) (_swig_ret ReachResponse) {
+	var swig_r ReachResponse
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7
+	_swig_i_7 := arg8
+	_swig_i_8 := arg9.Swigcptr()
+	swig_r = (ReachResponse)(SwigcptrReachResponse(C._wrap_Client_reachable_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4), C.swig_intgo(_swig_i_5), C.float(_swig_i_6), C._Bool(_swig_i_7), C.uintptr_t(_swig_i_8))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Map_match(arg2 int, arg3 float32, arg4 PointVector, arg5 FloatVector, arg6 float32, arg7 float32, arg8 float32, arg9 uint) (_swig_ret MatchResponse) {
+	var swig_r MatchResponse
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4.Swigcptr()
+	_swig_i_4 := arg5.Swigcptr()
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7
+	_swig_i_7 := arg8
+	_swig_i_8 := arg9
+	swig_r = (MatchResponse)(SwigcptrMatchResponse(C._wrap_Client_map_match_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.uintptr_t(_swig_i_3), C.uintptr_t(_swig_i_4), C.float(_swig_i_5), C.float(_swig_i_6), C.float(_swig_i_7), C.swig_intgo(_swig_i_8))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Distances(arg2 int, arg3 float32, arg4 Point, arg5 float32, arg6 float32, arg7 PointVector, arg8 FloatVector, arg9 FloatVector) (_swig_ret UnsignedVector) {
+	var swig_r UnsignedVector
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4.Swigcptr()
+	_swig_i_4 := arg5
+	_swig_i_5 := arg6
+	_swig_i_6 := arg7.Swigcptr()
+	_swig_i_7 := arg8.Swigcptr()
+	_swig_i_8 := arg9.Swigcptr()
+	swig_r = (UnsignedVector)(SwigcptrUnsignedVector(C._wrap_Client_distances_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.uintptr_t(_swig_i_3), C.float(_swig_i_4), C.float(_swig_i_5), C.uintptr_t(_swig_i_6), C.uintptr_t(_swig_i_7), C.uintptr_t(_swig_i_8))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Detailed_distances(arg2 int, arg3 float32, arg4 Point, arg5 PointVector, arg6 bool) (_swig_ret DistancesResponse) {
+	var swig_r DistancesResponse
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4.Swigcptr()
+	_swig_i_4 := arg5.Swigcptr()
+	_swig_i_5 := arg6
+	swig_r = (DistancesResponse)(SwigcptrDistancesResponse(C._wrap_Client_detailed_distances_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.uintptr_t(_swig_i_3), C.uintptr_t(_swig_i_4), C._Bool(_swig_i_5))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Nearest(arg2 int, arg3 float32, arg4 float32, arg5 float32) (_swig_ret Point) {
+	var swig_r Point
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	swig_r = (Point)(SwigcptrPoint(C._wrap_Client_nearest_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4))))
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Snap(arg2 int, arg3 float32, arg4 float32, arg5 float32) (_swig_ret SnapResponse) {
+	var swig_r SnapResponse
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	swig_r = (SnapResponse)(SwigcptrSnapResponse(C._wrap_Client_snap_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1), C.float(_swig_i_2), C.float(_swig_i_3), C.float(_swig_i_4))))
+	return swig_r
+}
+
+func NewClient(arg1 int, arg2 RoadNetwork, arg3 string, arg4 bool, arg5 bool) (_swig_ret Client) {
+	var swig_r Client
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2.Swigcptr()
+	_swig_i_2 := arg3
+	_swig_i_3 := arg4
+	_swig_i_4 := arg5
+	swig_r = (Client)(SwigcptrClient(C._wrap_new_Client_routingkit_75139fcf52884c4c(C.swig_intgo(_swig_i_0), C.uintptr_t(_swig_i_1), *(*C.swig_type_56)(unsafe.Pointer(&_swig_i_2)), C._Bool(_swig_i_3), C._Bool(_swig_i_4))))
+	if Swig_escape_always_false {
+		Swig_escape_val = arg3
+	}
+	return swig_r
+}
+
+func (arg1 SwigcptrClient) Load_error() (_swig_ret string) {
+	var swig_r string
+	_swig_i_0 := arg1
+	swig_r_p := C._wrap_Client_load_error_routingkit_75139fcf52884c4c(C.uintptr_t(_swig_i_0))
+	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
+	var swig_r_1 string
+ swig_r_1 = swigCopyString(swig_r) 
+	return swig_r_1
 }
 
 func DeleteClient(arg1 Client) {
@@ -1429,11 +2933,67 @@ func DeleteClient(arg1 Client) {
 type Client interface {
 	Swigcptr() uintptr
 	SwigIsClient()
-	Query(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 float32, arg7 float32, arg8 bool) (_swig_ret QueryResponse)
-	Distances(arg2 int, arg3 float32, arg4 Point, arg5 PointVector) (_swig_ret UnsignedVector)
+	Query(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 float32, arg7 float32, arg8 float32, arg9 float32, arg10 float32, arg11 float32, arg12 bool, arg13 bool) (_swig_ret QueryResponse)
+	Continue_query(arg2 int, arg3 float32, arg4 uint, arg5 float32, arg6 float32, arg7 float32, arg8 bool, arg9 bool) (_swig_ret QueryResponse)
+	Alternatives(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 float32, arg7 float32, arg8 uint, arg9 float32, arg10 float32, arg11 bool, arg12 bool) (_swig_ret QueryResponseVector)
+	Many_to_many(arg2 IntVector, arg3 float32, arg4 PointVector, arg5 PointVector, arg6 SWIGTYPE_p_unsigned_int, arg7 SWIGTYPE_p_int, arg8 SWIGTYPE_p_unsigned_int, arg9 SWIGTYPE_p_unsigned_int, arg10 SWIGTYPE_p_int) (_swig_ret uint)
+	Target_buckets(arg2 IntVector, arg3 float32, arg4 PointVector, arg5 uint) (_swig_ret TargetBuckets)
+	Many_to_many_rows(arg2 IntVector, arg3 TargetBuckets, arg4 float32, arg5 PointVector, arg6 SWIGTYPE_p_unsigned_int, arg7 SWIGTYPE_p_int, arg8 SWIGTYPE_p_unsigned_int, arg9 SWIGTYPE_p_unsigned_int, arg10 SWIGTYPE_p_int) (_swig_ret uint)
+	Sparse_many_to_many(arg2 IntVector, arg3 TargetBuckets, arg4 float32, arg5 PointVector, arg6 uint, arg7 SWIGTYPE_p_int) (_swig_ret UnsignedVector)
+	Nearest_targets(arg2 int, arg3 TargetBuckets, arg4 float32, arg5 Point, arg6 uint, arg7 uint) (_swig_ret UnsignedVector)
+	Node_count() (_swig_ret uint)
+	Node_positions(arg2 SWIGTYPE_p_float, arg3 SWIGTYPE_p_float)
+	One_to_all(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 SWIGTYPE_p_unsigned_int, arg7 SWIGTYPE_p_int) (_swig_ret bool)
+	Sample_costs(arg2 float32, arg3 float32, arg4 float32, arg5 SWIGTYPE_p_unsigned_int, arg6 PointVector, arg7 SWIGTYPE_p_unsigned_int)
+	Reachable(arg2 int, arg3 float32, arg4 float32, arg5 float32, arg6 uint, arg7 float32, arg8 bool, arg9 SWIGTYPE_p_int) (_swig_ret ReachResponse)
+	Map_match(arg2 int, arg3 float32, arg4 PointVector, arg5 FloatVector, arg6 float32, arg7 float32, arg8 float32, arg9 uint) (_swig_ret MatchResponse)
+	Distances(arg2 int, arg3 float32, arg4 Point, arg5 float32, arg6 float32, arg7 PointVector, arg8 FloatVector, arg9 FloatVector) (_swig_ret UnsignedVector)
+	Detailed_distances(arg2 int, arg3 float32, arg4 Point, arg5 PointVector, arg6 bool) (_swig_ret DistancesResponse)
 	Nearest(arg2 int, arg3 float32, arg4 float32, arg5 float32) (_swig_ret Point)
+	Snap(arg2 int, arg3 float32, arg4 float32, arg5 float32) (_swig_ret SnapResponse)
+	Load_error() (_swig_ret string)
+}
+
+
+type SwigcptrSWIGTYPE_p_float uintptr
+type SWIGTYPE_p_float interface {
+	Swigcptr() uintptr;
+}
+func (p SwigcptrSWIGTYPE_p_float) Swigcptr() uintptr {
+	return uintptr(p)
+}
+
+type SwigcptrSWIGTYPE_p_int uintptr
+type SWIGTYPE_p_int interface {
+	Swigcptr() uintptr;
+}
+func (p SwigcptrSWIGTYPE_p_int) Swigcptr() uintptr {
+	return uintptr(p)
+}
+
+type SwigcptrSWIGTYPE_p_std__vectorT_unsigned_char_t uintptr
+type SWIGTYPE_p_std__vectorT_unsigned_char_t interface {
+	Swigcptr() uintptr;
+}
+func (p SwigcptrSWIGTYPE_p_std__vectorT_unsigned_char_t) Swigcptr() uintptr {
+	return uintptr(p)
+}
+
+type SwigcptrSWIGTYPE_p_std__vectorT_unsigned_long_long_t uintptr
+type SWIGTYPE_p_std__vectorT_unsigned_long_long_t interface {
+	Swigcptr() uintptr;
+}
+func (p SwigcptrSWIGTYPE_p_std__vectorT_unsigned_long_long_t) Swigcptr() uintptr {
+	return uintptr(p)
 }
 
+type SwigcptrSWIGTYPE_p_unsigned_int uintptr
+type SWIGTYPE_p_unsigned_int interface {
+	Swigcptr() uintptr;
+}
+func (p SwigcptrSWIGTYPE_p_unsigned_int) Swigcptr() uintptr {
+	return uintptr(p)
+}
 
 type SwigcptrStd_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_ uintptr
 type Std_map_Sl_uint64_t_Sc_unsigned_SS_int_Sc_std_less_Sl_uint64_t_Sg__Sg_ interface {
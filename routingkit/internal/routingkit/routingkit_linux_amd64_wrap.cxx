@@ -306,6 +306,34 @@ SWIGINTERN void std_vector_Sl_Point_Sg__set(std::vector< Point > *self,int i,std
                 else
                     throw std::out_of_range("vector index out of range");
             }
+SWIGINTERN std::vector< Segment >::const_reference std_vector_Sl_Segment_Sg__get(std::vector< Segment > *self,int i){
+                int size = int(self->size());
+                if (i>=0 && i<size)
+                    return (*self)[i];
+                else
+                    throw std::out_of_range("vector index out of range");
+            }
+SWIGINTERN void std_vector_Sl_Segment_Sg__set(std::vector< Segment > *self,int i,std::vector< Segment >::value_type const &val){
+                int size = int(self->size());
+                if (i>=0 && i<size)
+                    (*self)[i] = val;
+                else
+                    throw std::out_of_range("vector index out of range");
+            }
+SWIGINTERN std::vector< QueryResponse >::const_reference std_vector_Sl_QueryResponse_Sg__get(std::vector< QueryResponse > *self,int i){
+                int size = int(self->size());
+                if (i>=0 && i<size)
+                    return (*self)[i];
+                else
+                    throw std::out_of_range("vector index out of range");
+            }
+SWIGINTERN void std_vector_Sl_QueryResponse_Sg__set(std::vector< QueryResponse > *self,int i,std::vector< QueryResponse >::value_type const &val){
+                int size = int(self->size());
+                if (i>=0 && i<size)
+                    (*self)[i] = val;
+                else
+                    throw std::out_of_range("vector index out of range");
+            }
 SWIGINTERN std::vector< unsigned int >::const_reference std_vector_Sl_unsigned_SS_int_Sg__get(std::vector< unsigned int > *self,int i){
                 int size = int(self->size());
                 if (i>=0 && i<size)
@@ -853,6 +881,320 @@ void _wrap_delete_PointVector_routingkit_32b576f51e679bfa(std::vector< Point > *
 }
 
 
+std::vector< Segment > *_wrap_new_SegmentVector__SWIG_0_routingkit_32b576f51e679bfa() {
+  std::vector< Segment > *result = 0 ;
+  std::vector< Segment > *_swig_go_result;
+  
+  
+  result = (std::vector< Segment > *)new std::vector< Segment >();
+  *(std::vector< Segment > **)&_swig_go_result = (std::vector< Segment > *)result; 
+  return _swig_go_result;
+}
+
+
+std::vector< Segment > *_wrap_new_SegmentVector__SWIG_1_routingkit_32b576f51e679bfa(long long _swig_go_0) {
+  std::vector< Segment >::size_type arg1 ;
+  std::vector< Segment > *result = 0 ;
+  std::vector< Segment > *_swig_go_result;
+  
+  arg1 = (size_t)_swig_go_0; 
+  
+  result = (std::vector< Segment > *)new std::vector< Segment >(arg1);
+  *(std::vector< Segment > **)&_swig_go_result = (std::vector< Segment > *)result; 
+  return _swig_go_result;
+}
+
+
+std::vector< Segment > *_wrap_new_SegmentVector__SWIG_2_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0) {
+  std::vector< Segment > *arg1 = 0 ;
+  std::vector< Segment > *result = 0 ;
+  std::vector< Segment > *_swig_go_result;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  
+  result = (std::vector< Segment > *)new std::vector< Segment >((std::vector< Segment > const &)*arg1);
+  *(std::vector< Segment > **)&_swig_go_result = (std::vector< Segment > *)result; 
+  return _swig_go_result;
+}
+
+
+long long _wrap_SegmentVector_size_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  std::vector< Segment >::size_type result;
+  long long _swig_go_result;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  
+  result = ((std::vector< Segment > const *)arg1)->size();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+long long _wrap_SegmentVector_capacity_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  std::vector< Segment >::size_type result;
+  long long _swig_go_result;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  
+  result = ((std::vector< Segment > const *)arg1)->capacity();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_SegmentVector_reserve_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0, long long _swig_go_1) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  std::vector< Segment >::size_type arg2 ;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  arg2 = (size_t)_swig_go_1; 
+  
+  (arg1)->reserve(arg2);
+  
+}
+
+
+bool _wrap_SegmentVector_isEmpty_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  bool result;
+  bool _swig_go_result;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  
+  result = (bool)((std::vector< Segment > const *)arg1)->empty();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_SegmentVector_clear_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  
+  (arg1)->clear();
+  
+}
+
+
+void _wrap_SegmentVector_add_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0, Segment *_swig_go_1) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  std::vector< Segment >::value_type *arg2 = 0 ;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  arg2 = *(std::vector< Segment >::value_type **)&_swig_go_1; 
+  
+  (arg1)->push_back((std::vector< Segment >::value_type const &)*arg2);
+  
+}
+
+
+Segment *_wrap_SegmentVector_get_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0, intgo _swig_go_1) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  int arg2 ;
+  std::vector< Segment >::value_type *result = 0 ;
+  Segment *_swig_go_result;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  
+  try {
+    result = (std::vector< Segment >::value_type *) &std_vector_Sl_Segment_Sg__get(arg1,arg2);
+  } catch(std::out_of_range &_e) {
+    _swig_gopanic((&_e)->what());
+  }
+  *(std::vector< Segment >::value_type **)&_swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_SegmentVector_set_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0, intgo _swig_go_1, Segment *_swig_go_2) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  int arg2 ;
+  std::vector< Segment >::value_type *arg3 = 0 ;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = *(std::vector< Segment >::value_type **)&_swig_go_2; 
+  
+  try {
+    std_vector_Sl_Segment_Sg__set(arg1,arg2,(Segment const &)*arg3);
+  } catch(std::out_of_range &_e) {
+    _swig_gopanic((&_e)->what());
+  }
+  
+}
+
+
+void _wrap_delete_SegmentVector_routingkit_32b576f51e679bfa(std::vector< Segment > *_swig_go_0) {
+  std::vector< Segment > *arg1 = (std::vector< Segment > *) 0 ;
+  
+  arg1 = *(std::vector< Segment > **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+std::vector< QueryResponse > *_wrap_new_QueryResponseVector__SWIG_0_routingkit_32b576f51e679bfa() {
+  std::vector< QueryResponse > *result = 0 ;
+  std::vector< QueryResponse > *_swig_go_result;
+  
+  
+  result = (std::vector< QueryResponse > *)new std::vector< QueryResponse >();
+  *(std::vector< QueryResponse > **)&_swig_go_result = (std::vector< QueryResponse > *)result; 
+  return _swig_go_result;
+}
+
+
+std::vector< QueryResponse > *_wrap_new_QueryResponseVector__SWIG_1_routingkit_32b576f51e679bfa(long long _swig_go_0) {
+  std::vector< QueryResponse >::size_type arg1 ;
+  std::vector< QueryResponse > *result = 0 ;
+  std::vector< QueryResponse > *_swig_go_result;
+  
+  arg1 = (size_t)_swig_go_0; 
+  
+  result = (std::vector< QueryResponse > *)new std::vector< QueryResponse >(arg1);
+  *(std::vector< QueryResponse > **)&_swig_go_result = (std::vector< QueryResponse > *)result; 
+  return _swig_go_result;
+}
+
+
+std::vector< QueryResponse > *_wrap_new_QueryResponseVector__SWIG_2_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0) {
+  std::vector< QueryResponse > *arg1 = 0 ;
+  std::vector< QueryResponse > *result = 0 ;
+  std::vector< QueryResponse > *_swig_go_result;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  
+  result = (std::vector< QueryResponse > *)new std::vector< QueryResponse >((std::vector< QueryResponse > const &)*arg1);
+  *(std::vector< QueryResponse > **)&_swig_go_result = (std::vector< QueryResponse > *)result; 
+  return _swig_go_result;
+}
+
+
+long long _wrap_QueryResponseVector_size_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  std::vector< QueryResponse >::size_type result;
+  long long _swig_go_result;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  
+  result = ((std::vector< QueryResponse > const *)arg1)->size();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+long long _wrap_QueryResponseVector_capacity_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  std::vector< QueryResponse >::size_type result;
+  long long _swig_go_result;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  
+  result = ((std::vector< QueryResponse > const *)arg1)->capacity();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponseVector_reserve_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0, long long _swig_go_1) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  std::vector< QueryResponse >::size_type arg2 ;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  arg2 = (size_t)_swig_go_1; 
+  
+  (arg1)->reserve(arg2);
+  
+}
+
+
+bool _wrap_QueryResponseVector_isEmpty_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  bool result;
+  bool _swig_go_result;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  
+  result = (bool)((std::vector< QueryResponse > const *)arg1)->empty();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponseVector_clear_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  
+  (arg1)->clear();
+  
+}
+
+
+void _wrap_QueryResponseVector_add_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0, QueryResponse *_swig_go_1) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  std::vector< QueryResponse >::value_type *arg2 = 0 ;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  arg2 = *(std::vector< QueryResponse >::value_type **)&_swig_go_1; 
+  
+  (arg1)->push_back((std::vector< QueryResponse >::value_type const &)*arg2);
+  
+}
+
+
+QueryResponse *_wrap_QueryResponseVector_get_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0, intgo _swig_go_1) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  int arg2 ;
+  std::vector< QueryResponse >::value_type *result = 0 ;
+  QueryResponse *_swig_go_result;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  
+  try {
+    result = (std::vector< QueryResponse >::value_type *) &std_vector_Sl_QueryResponse_Sg__get(arg1,arg2);
+  } catch(std::out_of_range &_e) {
+    _swig_gopanic((&_e)->what());
+  }
+  *(std::vector< QueryResponse >::value_type **)&_swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponseVector_set_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0, intgo _swig_go_1, QueryResponse *_swig_go_2) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  int arg2 ;
+  std::vector< QueryResponse >::value_type *arg3 = 0 ;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = *(std::vector< QueryResponse >::value_type **)&_swig_go_2; 
+  
+  try {
+    std_vector_Sl_QueryResponse_Sg__set(arg1,arg2,(QueryResponse const &)*arg3);
+  } catch(std::out_of_range &_e) {
+    _swig_gopanic((&_e)->what());
+  }
+  
+}
+
+
+void _wrap_delete_QueryResponseVector_routingkit_32b576f51e679bfa(std::vector< QueryResponse > *_swig_go_0) {
+  std::vector< QueryResponse > *arg1 = (std::vector< QueryResponse > *) 0 ;
+  
+  arg1 = *(std::vector< QueryResponse > **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
 std::vector< unsigned int > *_wrap_new_UnsignedVector__SWIG_0_routingkit_32b576f51e679bfa() {
   std::vector< unsigned int > *result = 0 ;
   std::vector< unsigned int > *_swig_go_result;
@@ -1376,193 +1718,158 @@ void _wrap_delete_Point_routingkit_32b576f51e679bfa(Point *_swig_go_0) {
 }
 
 
-void _wrap_QueryResponse_distance_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, intgo _swig_go_1) {
-  QueryResponse *arg1 = (QueryResponse *) 0 ;
-  unsigned int arg2 ;
+intgo _wrap_status_ok_routingkit_32b576f51e679bfa() {
+  query_status result;
+  intgo _swig_go_result;
   
-  arg1 = *(QueryResponse **)&_swig_go_0; 
-  arg2 = (unsigned int)_swig_go_1; 
   
-  if (arg1) (arg1)->distance = arg2;
+  result = status_ok;
   
+  _swig_go_result = (intgo)result; 
+  return _swig_go_result;
 }
 
 
-intgo _wrap_QueryResponse_distance_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
-  QueryResponse *arg1 = (QueryResponse *) 0 ;
-  unsigned int result;
+intgo _wrap_status_source_not_snapped_routingkit_32b576f51e679bfa() {
+  query_status result;
   intgo _swig_go_result;
   
-  arg1 = *(QueryResponse **)&_swig_go_0; 
   
-  result = (unsigned int) ((arg1)->distance);
-  _swig_go_result = result; 
+  result = status_source_not_snapped;
+  
+  _swig_go_result = (intgo)result; 
   return _swig_go_result;
 }
 
 
-void _wrap_QueryResponse_waypoints_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, std::vector< Point > *_swig_go_1) {
-  QueryResponse *arg1 = (QueryResponse *) 0 ;
-  std::vector< Point > *arg2 = (std::vector< Point > *) 0 ;
+intgo _wrap_status_target_not_snapped_routingkit_32b576f51e679bfa() {
+  query_status result;
+  intgo _swig_go_result;
   
-  arg1 = *(QueryResponse **)&_swig_go_0; 
-  arg2 = *(std::vector< Point > **)&_swig_go_1; 
   
-  if (arg1) (arg1)->waypoints = *arg2;
+  result = status_target_not_snapped;
   
+  _swig_go_result = (intgo)result; 
+  return _swig_go_result;
 }
 
 
-std::vector< Point > *_wrap_QueryResponse_waypoints_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
-  QueryResponse *arg1 = (QueryResponse *) 0 ;
-  std::vector< Point > *result = 0 ;
-  std::vector< Point > *_swig_go_result;
+intgo _wrap_status_no_path_routingkit_32b576f51e679bfa() {
+  query_status result;
+  intgo _swig_go_result;
   
-  arg1 = *(QueryResponse **)&_swig_go_0; 
   
-  result = (std::vector< Point > *)& ((arg1)->waypoints);
-  *(std::vector< Point > **)&_swig_go_result = (std::vector< Point > *)result; 
+  result = status_no_path;
+  
+  _swig_go_result = (intgo)result; 
   return _swig_go_result;
 }
 
 
-QueryResponse *_wrap_new_QueryResponse_routingkit_32b576f51e679bfa() {
-  QueryResponse *result = 0 ;
-  QueryResponse *_swig_go_result;
+void _wrap_Segment_way_id_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, long long _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  long long arg2 ;
   
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (long long)_swig_go_1; 
   
-  result = (QueryResponse *)new QueryResponse();
-  *(QueryResponse **)&_swig_go_result = (QueryResponse *)result; 
-  return _swig_go_result;
-}
-
-
-void _wrap_delete_QueryResponse_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
-  QueryResponse *arg1 = (QueryResponse *) 0 ;
-  
-  arg1 = *(QueryResponse **)&_swig_go_0; 
-  
-  delete arg1;
-  
-}
-
-
-intgo _wrap_vehicle_routingkit_32b576f51e679bfa() {
-  transport_mode result;
-  intgo _swig_go_result;
-  
+  if (arg1) (arg1)->way_id = arg2;
   
-  result = vehicle;
-  
-  _swig_go_result = (intgo)result; 
-  return _swig_go_result;
 }
 
 
-intgo _wrap_bike_routingkit_32b576f51e679bfa() {
-  transport_mode result;
-  intgo _swig_go_result;
-  
+long long _wrap_Segment_way_id_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  long long result;
+  long long _swig_go_result;
   
-  result = bike;
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  _swig_go_result = (intgo)result; 
+  result = (long long) ((arg1)->way_id);
+  _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-intgo _wrap_pedestrian_routingkit_32b576f51e679bfa() {
-  transport_mode result;
-  intgo _swig_go_result;
-  
+void _wrap_Segment_name_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, _gostring_ _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  char *arg2 = (char *) 0 ;
   
-  result = pedestrian;
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  _swig_go_result = (intgo)result; 
-  return _swig_go_result;
-}
-
-
-void _wrap_Profile_allowedWayIds_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, std::vector< int > *_swig_go_1) {
-  Profile *arg1 = (Profile *) 0 ;
-  std::vector< int > *arg2 = (std::vector< int > *) 0 ;
+  arg2 = (char *)malloc(_swig_go_1.n + 1);
+  memcpy(arg2, _swig_go_1.p, _swig_go_1.n);
+  arg2[_swig_go_1.n] = '\0';
   
-  arg1 = *(Profile **)&_swig_go_0; 
-  arg2 = *(std::vector< int > **)&_swig_go_1; 
   
-  if (arg1) (arg1)->allowedWayIds = *arg2;
+  {
+    if (arg2) {
+      arg1->name = (char const *) (new char[strlen((const char *)arg2)+1]);
+      strcpy((char *)arg1->name, (const char *)arg2);
+    } else {
+      arg1->name = 0;
+    }
+  }
   
+  free(arg2); 
 }
 
 
-std::vector< int > *_wrap_Profile_allowedWayIds_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
-  std::vector< int > *result = 0 ;
-  std::vector< int > *_swig_go_result;
+_gostring_ _wrap_Segment_name_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  char *result = 0 ;
+  _gostring_ _swig_go_result;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (std::vector< int > *)& ((arg1)->allowedWayIds);
-  *(std::vector< int > **)&_swig_go_result = (std::vector< int > *)result; 
+  result = (char *) ((arg1)->name);
+  _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
   return _swig_go_result;
 }
 
 
-void _wrap_Profile_waySpeeds_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, std::map< uint64_t,unsigned int,std::less< uint64_t > > *_swig_go_1) {
-  Profile *arg1 = (Profile *) 0 ;
-  std::map< uint64_t,unsigned int,std::less< uint64_t > > *arg2 = (std::map< uint64_t,unsigned int,std::less< uint64_t > > *) 0 ;
-  
-  arg1 = *(Profile **)&_swig_go_0; 
-  arg2 = *(std::map< uint64_t,unsigned int,std::less< uint64_t > > **)&_swig_go_1; 
-  
-  if (arg1) (arg1)->waySpeeds = *arg2;
-  
-}
-
-
-std::map< uint64_t,unsigned int,std::less< uint64_t > > *_wrap_Profile_waySpeeds_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
-  std::map< uint64_t,unsigned int,std::less< uint64_t > > *result = 0 ;
-  std::map< uint64_t,unsigned int,std::less< uint64_t > > *_swig_go_result;
+void _wrap_Segment_ref_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, _gostring_ _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  char *arg2 = (char *) 0 ;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (std::map< uint64_t,unsigned int,std::less< uint64_t > > *)& ((arg1)->waySpeeds);
-  *(std::map< uint64_t,unsigned int,std::less< uint64_t > > **)&_swig_go_result = (std::map< uint64_t,unsigned int,std::less< uint64_t > > *)result; 
-  return _swig_go_result;
-}
-
-
-void _wrap_Profile_transportMode_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, intgo _swig_go_1) {
-  Profile *arg1 = (Profile *) 0 ;
-  transport_mode arg2 ;
+  arg2 = (char *)malloc(_swig_go_1.n + 1);
+  memcpy(arg2, _swig_go_1.p, _swig_go_1.n);
+  arg2[_swig_go_1.n] = '\0';
   
-  arg1 = *(Profile **)&_swig_go_0; 
-  arg2 = (transport_mode)_swig_go_1; 
   
-  if (arg1) (arg1)->transportMode = arg2;
+  {
+    if (arg2) {
+      arg1->ref = (char const *) (new char[strlen((const char *)arg2)+1]);
+      strcpy((char *)arg1->ref, (const char *)arg2);
+    } else {
+      arg1->ref = 0;
+    }
+  }
   
+  free(arg2); 
 }
 
 
-intgo _wrap_Profile_transportMode_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
-  transport_mode result;
-  intgo _swig_go_result;
+_gostring_ _wrap_Segment_ref_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  char *result = 0 ;
+  _gostring_ _swig_go_result;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (transport_mode) ((arg1)->transportMode);
-  _swig_go_result = (intgo)result; 
+  result = (char *) ((arg1)->ref);
+  _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
   return _swig_go_result;
 }
 
 
-void _wrap_Profile_name_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, _gostring_ _swig_go_1) {
-  Profile *arg1 = (Profile *) 0 ;
+void _wrap_Segment_highway_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, _gostring_ _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
   char *arg2 = (char *) 0 ;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
   arg2 = (char *)malloc(_swig_go_1.n + 1);
   memcpy(arg2, _swig_go_1.p, _swig_go_1.n);
@@ -1571,10 +1878,10 @@ void _wrap_Profile_name_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, _go
   
   {
     if (arg2) {
-      arg1->name = (char const *) (new char[strlen((const char *)arg2)+1]);
-      strcpy((char *)arg1->name, (const char *)arg2);
+      arg1->highway = (char const *) (new char[strlen((const char *)arg2)+1]);
+      strcpy((char *)arg1->highway, (const char *)arg2);
     } else {
-      arg1->name = 0;
+      arg1->highway = 0;
     }
   }
   
@@ -1582,408 +1889,2230 @@ void _wrap_Profile_name_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, _go
 }
 
 
-_gostring_ _wrap_Profile_name_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
+_gostring_ _wrap_Segment_highway_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
   char *result = 0 ;
   _gostring_ _swig_go_result;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (char *) ((arg1)->name);
+  result = (char *) ((arg1)->highway);
   _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
   return _swig_go_result;
 }
 
 
-void _wrap_Profile_prevent_left_turns_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, bool _swig_go_1) {
-  Profile *arg1 = (Profile *) 0 ;
-  bool arg2 ;
+void _wrap_Segment_distance_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, intgo _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int arg2 ;
   
-  arg1 = *(Profile **)&_swig_go_0; 
-  arg2 = (bool)_swig_go_1; 
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
   
-  if (arg1) (arg1)->prevent_left_turns = arg2;
+  if (arg1) (arg1)->distance = arg2;
   
 }
 
 
-bool _wrap_Profile_prevent_left_turns_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
-  bool result;
-  bool _swig_go_result;
+intgo _wrap_Segment_distance_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (bool) ((arg1)->prevent_left_turns);
+  result = (unsigned int) ((arg1)->distance);
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_Profile_prevent_u_turns_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, bool _swig_go_1) {
-  Profile *arg1 = (Profile *) 0 ;
-  bool arg2 ;
+void _wrap_Segment_duration_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, intgo _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int arg2 ;
   
-  arg1 = *(Profile **)&_swig_go_0; 
-  arg2 = (bool)_swig_go_1; 
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
   
-  if (arg1) (arg1)->prevent_u_turns = arg2;
+  if (arg1) (arg1)->duration = arg2;
   
 }
 
 
-bool _wrap_Profile_prevent_u_turns_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
-  bool result;
-  bool _swig_go_result;
+intgo _wrap_Segment_duration_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (bool) ((arg1)->prevent_u_turns);
+  result = (unsigned int) ((arg1)->duration);
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_Profile_travel_time_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, bool _swig_go_1) {
-  Profile *arg1 = (Profile *) 0 ;
-  bool arg2 ;
+void _wrap_Segment_speed_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, intgo _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int arg2 ;
   
-  arg1 = *(Profile **)&_swig_go_0; 
-  arg2 = (bool)_swig_go_1; 
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
   
-  if (arg1) (arg1)->travel_time = arg2;
+  if (arg1) (arg1)->speed = arg2;
   
 }
 
 
-bool _wrap_Profile_travel_time_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
-  bool result;
-  bool _swig_go_result;
+intgo _wrap_Segment_speed_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (bool) ((arg1)->travel_time);
+  result = (unsigned int) ((arg1)->speed);
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-Profile *_wrap_new_Profile_routingkit_32b576f51e679bfa() {
-  Profile *result = 0 ;
-  Profile *_swig_go_result;
+void _wrap_Segment_from_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, Point *_swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  Point *arg2 = (Point *) 0 ;
   
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = *(Point **)&_swig_go_1; 
   
-  result = (Profile *)new Profile();
-  *(Profile **)&_swig_go_result = (Profile *)result; 
+  if (arg1) (arg1)->from = *arg2;
+  
+}
+
+
+Point *_wrap_Segment_from_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  Point *result = 0 ;
+  Point *_swig_go_result;
+  
+  arg1 = *(Segment **)&_swig_go_0; 
+  
+  result = (Point *)& ((arg1)->from);
+  *(Point **)&_swig_go_result = (Point *)result; 
   return _swig_go_result;
 }
 
 
-void _wrap_delete_Profile_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
-  Profile *arg1 = (Profile *) 0 ;
+void _wrap_Segment_to_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, Point *_swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  Point *arg2 = (Point *) 0 ;
   
-  arg1 = *(Profile **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = *(Point **)&_swig_go_1; 
   
-  delete arg1;
+  if (arg1) (arg1)->to = *arg2;
   
 }
 
 
-intgo _wrap_max_distance_get_routingkit_32b576f51e679bfa() {
-  unsigned int result;
-  intgo _swig_go_result;
+Point *_wrap_Segment_to_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  Point *result = 0 ;
+  Point *_swig_go_result;
   
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (unsigned int)(unsigned int)GoRoutingKit::max_distance;
-  _swig_go_result = result; 
+  result = (Point *)& ((arg1)->to);
+  *(Point **)&_swig_go_result = (Point *)result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_first_out_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+void _wrap_Segment_start_bearing_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, float _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  float arg2 ;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
-  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (float)_swig_go_1; 
   
-  if (arg1) (arg1)->first_out = *arg2;
+  if (arg1) (arg1)->start_bearing = arg2;
   
 }
 
 
-std::vector< unsigned int > *_wrap_RoutingGraph_first_out_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *result = 0 ;
-  std::vector< unsigned int > *_swig_go_result;
+float _wrap_Segment_start_bearing_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  float result;
+  float _swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (std::vector< unsigned int > *)& ((arg1)->first_out);
-  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  result = (float) ((arg1)->start_bearing);
+  _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_head_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+void _wrap_Segment_end_bearing_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, float _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  float arg2 ;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
-  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (float)_swig_go_1; 
   
-  if (arg1) (arg1)->head = *arg2;
+  if (arg1) (arg1)->end_bearing = arg2;
   
 }
 
 
-std::vector< unsigned int > *_wrap_RoutingGraph_head_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *result = 0 ;
-  std::vector< unsigned int > *_swig_go_result;
+float _wrap_Segment_end_bearing_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  float result;
+  float _swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (std::vector< unsigned int > *)& ((arg1)->head);
-  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  result = (float) ((arg1)->end_bearing);
+  _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_travel_time_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+void _wrap_Segment_roundabout_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, bool _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  bool arg2 ;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
-  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (bool)_swig_go_1; 
   
-  if (arg1) (arg1)->travel_time = *arg2;
+  if (arg1) (arg1)->roundabout = arg2;
   
 }
 
 
-std::vector< unsigned int > *_wrap_RoutingGraph_travel_time_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *result = 0 ;
-  std::vector< unsigned int > *_swig_go_result;
+bool _wrap_Segment_roundabout_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  bool result;
+  bool _swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(Segment **)&_swig_go_0; 
   
-  result = (std::vector< unsigned int > *)& ((arg1)->travel_time);
+  result = (bool) ((arg1)->roundabout);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_Segment_branches_set_routingkit_32b576f51e679bfa(Segment *_swig_go_0, intgo _swig_go_1) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int arg2 ;
+  
+  arg1 = *(Segment **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
+  
+  if (arg1) (arg1)->branches = arg2;
+  
+}
+
+
+intgo _wrap_Segment_branches_get_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(Segment **)&_swig_go_0; 
+  
+  result = (unsigned int) ((arg1)->branches);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+Segment *_wrap_new_Segment_routingkit_32b576f51e679bfa() {
+  Segment *result = 0 ;
+  Segment *_swig_go_result;
+  
+  
+  result = (Segment *)new Segment();
+  *(Segment **)&_swig_go_result = (Segment *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_Segment_routingkit_32b576f51e679bfa(Segment *_swig_go_0) {
+  Segment *arg1 = (Segment *) 0 ;
+  
+  arg1 = *(Segment **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+void _wrap_QueryResponse_status_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, intgo _swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  query_status arg2 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = (query_status)_swig_go_1; 
+  
+  if (arg1) (arg1)->status = arg2;
+  
+}
+
+
+intgo _wrap_QueryResponse_status_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  query_status result;
+  intgo _swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (query_status) ((arg1)->status);
+  _swig_go_result = (intgo)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_distance_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, intgo _swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int arg2 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
+  
+  if (arg1) (arg1)->distance = arg2;
+  
+}
+
+
+intgo _wrap_QueryResponse_distance_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (unsigned int) ((arg1)->distance);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_geo_distance_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, intgo _swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int arg2 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
+  
+  if (arg1) (arg1)->geo_distance = arg2;
+  
+}
+
+
+intgo _wrap_QueryResponse_geo_distance_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (unsigned int) ((arg1)->geo_distance);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_travel_time_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, intgo _swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int arg2 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
+  
+  if (arg1) (arg1)->travel_time = arg2;
+  
+}
+
+
+intgo _wrap_QueryResponse_travel_time_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (unsigned int) ((arg1)->travel_time);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_waypoints_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, std::vector< Point > *_swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  std::vector< Point > *arg2 = (std::vector< Point > *) 0 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< Point > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->waypoints = *arg2;
+  
+}
+
+
+std::vector< Point > *_wrap_QueryResponse_waypoints_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  std::vector< Point > *result = 0 ;
+  std::vector< Point > *_swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (std::vector< Point > *)& ((arg1)->waypoints);
+  *(std::vector< Point > **)&_swig_go_result = (std::vector< Point > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_source_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, Point *_swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  Point *arg2 = (Point *) 0 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = *(Point **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->source = *arg2;
+  
+}
+
+
+Point *_wrap_QueryResponse_source_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  Point *result = 0 ;
+  Point *_swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (Point *)& ((arg1)->source);
+  *(Point **)&_swig_go_result = (Point *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_target_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, Point *_swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  Point *arg2 = (Point *) 0 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = *(Point **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->target = *arg2;
+  
+}
+
+
+Point *_wrap_QueryResponse_target_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  Point *result = 0 ;
+  Point *_swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (Point *)& ((arg1)->target);
+  *(Point **)&_swig_go_result = (Point *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_segments_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, std::vector< Segment > *_swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  std::vector< Segment > *arg2 = (std::vector< Segment > *) 0 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< Segment > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->segments = *arg2;
+  
+}
+
+
+std::vector< Segment > *_wrap_QueryResponse_segments_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  std::vector< Segment > *result = 0 ;
+  std::vector< Segment > *_swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (std::vector< Segment > *)& ((arg1)->segments);
+  *(std::vector< Segment > **)&_swig_go_result = (std::vector< Segment > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_last_arc_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, intgo _swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int arg2 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
+  
+  if (arg1) (arg1)->last_arc = arg2;
+  
+}
+
+
+intgo _wrap_QueryResponse_last_arc_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (unsigned int) ((arg1)->last_arc);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_QueryResponse_last_fraction_set_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0, float _swig_go_1) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  float arg2 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  arg2 = (float)_swig_go_1; 
+  
+  if (arg1) (arg1)->last_fraction = arg2;
+  
+}
+
+
+float _wrap_QueryResponse_last_fraction_get_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  float result;
+  float _swig_go_result;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  result = (float) ((arg1)->last_fraction);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+QueryResponse *_wrap_new_QueryResponse_routingkit_32b576f51e679bfa() {
+  QueryResponse *result = 0 ;
+  QueryResponse *_swig_go_result;
+  
+  
+  result = (QueryResponse *)new QueryResponse();
+  *(QueryResponse **)&_swig_go_result = (QueryResponse *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_QueryResponse_routingkit_32b576f51e679bfa(QueryResponse *_swig_go_0) {
+  QueryResponse *arg1 = (QueryResponse *) 0 ;
+  
+  arg1 = *(QueryResponse **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+void _wrap_SnapResponse_snapped_set_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0, bool _swig_go_1) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  bool arg2 ;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  arg2 = (bool)_swig_go_1; 
+  
+  if (arg1) (arg1)->snapped = arg2;
+  
+}
+
+
+bool _wrap_SnapResponse_snapped_get_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  bool result;
+  bool _swig_go_result;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  result = (bool) ((arg1)->snapped);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_SnapResponse_point_set_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0, Point *_swig_go_1) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  Point *arg2 = (Point *) 0 ;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  arg2 = *(Point **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->point = *arg2;
+  
+}
+
+
+Point *_wrap_SnapResponse_point_get_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  Point *result = 0 ;
+  Point *_swig_go_result;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  result = (Point *)& ((arg1)->point);
+  *(Point **)&_swig_go_result = (Point *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_SnapResponse_distance_set_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0, float _swig_go_1) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  float arg2 ;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  arg2 = (float)_swig_go_1; 
+  
+  if (arg1) (arg1)->distance = arg2;
+  
+}
+
+
+float _wrap_SnapResponse_distance_get_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  float result;
+  float _swig_go_result;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  result = (float) ((arg1)->distance);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_SnapResponse_way_id_set_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0, long long _swig_go_1) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  long long arg2 ;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  arg2 = (long long)_swig_go_1; 
+  
+  if (arg1) (arg1)->way_id = arg2;
+  
+}
+
+
+long long _wrap_SnapResponse_way_id_get_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  long long result;
+  long long _swig_go_result;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  result = (long long) ((arg1)->way_id);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_SnapResponse_name_set_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0, _gostring_ _swig_go_1) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  char *arg2 = (char *) 0 ;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  arg2 = (char *)malloc(_swig_go_1.n + 1);
+  memcpy(arg2, _swig_go_1.p, _swig_go_1.n);
+  arg2[_swig_go_1.n] = '\0';
+  
+  
+  {
+    if (arg2) {
+      arg1->name = (char const *) (new char[strlen((const char *)arg2)+1]);
+      strcpy((char *)arg1->name, (const char *)arg2);
+    } else {
+      arg1->name = 0;
+    }
+  }
+  
+  free(arg2); 
+}
+
+
+_gostring_ _wrap_SnapResponse_name_get_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  char *result = 0 ;
+  _gostring_ _swig_go_result;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  result = (char *) ((arg1)->name);
+  _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
+  return _swig_go_result;
+}
+
+
+void _wrap_SnapResponse_highway_set_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0, _gostring_ _swig_go_1) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  char *arg2 = (char *) 0 ;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  arg2 = (char *)malloc(_swig_go_1.n + 1);
+  memcpy(arg2, _swig_go_1.p, _swig_go_1.n);
+  arg2[_swig_go_1.n] = '\0';
+  
+  
+  {
+    if (arg2) {
+      arg1->highway = (char const *) (new char[strlen((const char *)arg2)+1]);
+      strcpy((char *)arg1->highway, (const char *)arg2);
+    } else {
+      arg1->highway = 0;
+    }
+  }
+  
+  free(arg2); 
+}
+
+
+_gostring_ _wrap_SnapResponse_highway_get_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  char *result = 0 ;
+  _gostring_ _swig_go_result;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  result = (char *) ((arg1)->highway);
+  _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
+  return _swig_go_result;
+}
+
+
+SnapResponse *_wrap_new_SnapResponse_routingkit_32b576f51e679bfa() {
+  SnapResponse *result = 0 ;
+  SnapResponse *_swig_go_result;
+  
+  
+  result = (SnapResponse *)new SnapResponse();
+  *(SnapResponse **)&_swig_go_result = (SnapResponse *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_SnapResponse_routingkit_32b576f51e679bfa(SnapResponse *_swig_go_0) {
+  SnapResponse *arg1 = (SnapResponse *) 0 ;
+  
+  arg1 = *(SnapResponse **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+void _wrap_DistancesResponse_distances_set_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->distances = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_DistancesResponse_distances_get_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->distances);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_DistancesResponse_statuses_set_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0, std::vector< int > *_swig_go_1) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< int > *arg2 = (std::vector< int > *) 0 ;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->statuses = *arg2;
+  
+}
+
+
+std::vector< int > *_wrap_DistancesResponse_statuses_get_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< int > *result = 0 ;
+  std::vector< int > *_swig_go_result;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  
+  result = (std::vector< int > *)& ((arg1)->statuses);
+  *(std::vector< int > **)&_swig_go_result = (std::vector< int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_DistancesResponse_geo_distances_set_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->geo_distances = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_DistancesResponse_geo_distances_get_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->geo_distances);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_DistancesResponse_travel_times_set_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->travel_times = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_DistancesResponse_travel_times_get_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->travel_times);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+DistancesResponse *_wrap_new_DistancesResponse_routingkit_32b576f51e679bfa() {
+  DistancesResponse *result = 0 ;
+  DistancesResponse *_swig_go_result;
+  
+  
+  result = (DistancesResponse *)new DistancesResponse();
+  *(DistancesResponse **)&_swig_go_result = (DistancesResponse *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_DistancesResponse_routingkit_32b576f51e679bfa(DistancesResponse *_swig_go_0) {
+  DistancesResponse *arg1 = (DistancesResponse *) 0 ;
+  
+  arg1 = *(DistancesResponse **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+void _wrap_ReachResponse_points_set_routingkit_32b576f51e679bfa(ReachResponse *_swig_go_0, std::vector< Point > *_swig_go_1) {
+  ReachResponse *arg1 = (ReachResponse *) 0 ;
+  std::vector< Point > *arg2 = (std::vector< Point > *) 0 ;
+  
+  arg1 = *(ReachResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< Point > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->points = *arg2;
+  
+}
+
+
+std::vector< Point > *_wrap_ReachResponse_points_get_routingkit_32b576f51e679bfa(ReachResponse *_swig_go_0) {
+  ReachResponse *arg1 = (ReachResponse *) 0 ;
+  std::vector< Point > *result = 0 ;
+  std::vector< Point > *_swig_go_result;
+  
+  arg1 = *(ReachResponse **)&_swig_go_0; 
+  
+  result = (std::vector< Point > *)& ((arg1)->points);
+  *(std::vector< Point > **)&_swig_go_result = (std::vector< Point > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_ReachResponse_costs_set_routingkit_32b576f51e679bfa(ReachResponse *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  ReachResponse *arg1 = (ReachResponse *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(ReachResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->costs = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_ReachResponse_costs_get_routingkit_32b576f51e679bfa(ReachResponse *_swig_go_0) {
+  ReachResponse *arg1 = (ReachResponse *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(ReachResponse **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->costs);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+ReachResponse *_wrap_new_ReachResponse_routingkit_32b576f51e679bfa() {
+  ReachResponse *result = 0 ;
+  ReachResponse *_swig_go_result;
+  
+  
+  result = (ReachResponse *)new ReachResponse();
+  *(ReachResponse **)&_swig_go_result = (ReachResponse *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_ReachResponse_routingkit_32b576f51e679bfa(ReachResponse *_swig_go_0) {
+  ReachResponse *arg1 = (ReachResponse *) 0 ;
+  
+  arg1 = *(ReachResponse **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+void _wrap_MatchResponse_points_set_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0, std::vector< Point > *_swig_go_1) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< Point > *arg2 = (std::vector< Point > *) 0 ;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< Point > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->points = *arg2;
+  
+}
+
+
+std::vector< Point > *_wrap_MatchResponse_points_get_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< Point > *result = 0 ;
+  std::vector< Point > *_swig_go_result;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  
+  result = (std::vector< Point > *)& ((arg1)->points);
+  *(std::vector< Point > **)&_swig_go_result = (std::vector< Point > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_MatchResponse_matched_set_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0, std::vector< int > *_swig_go_1) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< int > *arg2 = (std::vector< int > *) 0 ;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->matched = *arg2;
+  
+}
+
+
+std::vector< int > *_wrap_MatchResponse_matched_get_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< int > *result = 0 ;
+  std::vector< int > *_swig_go_result;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  
+  result = (std::vector< int > *)& ((arg1)->matched);
+  *(std::vector< int > **)&_swig_go_result = (std::vector< int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_MatchResponse_confidences_set_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0, std::vector< float > *_swig_go_1) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< float > *arg2 = (std::vector< float > *) 0 ;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< float > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->confidences = *arg2;
+  
+}
+
+
+std::vector< float > *_wrap_MatchResponse_confidences_get_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< float > *result = 0 ;
+  std::vector< float > *_swig_go_result;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  
+  result = (std::vector< float > *)& ((arg1)->confidences);
+  *(std::vector< float > **)&_swig_go_result = (std::vector< float > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_MatchResponse_path_set_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0, std::vector< Point > *_swig_go_1) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< Point > *arg2 = (std::vector< Point > *) 0 ;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  arg2 = *(std::vector< Point > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->path = *arg2;
+  
+}
+
+
+std::vector< Point > *_wrap_MatchResponse_path_get_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  std::vector< Point > *result = 0 ;
+  std::vector< Point > *_swig_go_result;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  
+  result = (std::vector< Point > *)& ((arg1)->path);
+  *(std::vector< Point > **)&_swig_go_result = (std::vector< Point > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_MatchResponse_distance_set_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0, intgo _swig_go_1) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  unsigned int arg2 ;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  arg2 = (unsigned int)_swig_go_1; 
+  
+  if (arg1) (arg1)->distance = arg2;
+  
+}
+
+
+intgo _wrap_MatchResponse_distance_get_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  
+  result = (unsigned int) ((arg1)->distance);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+MatchResponse *_wrap_new_MatchResponse_routingkit_32b576f51e679bfa() {
+  MatchResponse *result = 0 ;
+  MatchResponse *_swig_go_result;
+  
+  
+  result = (MatchResponse *)new MatchResponse();
+  *(MatchResponse **)&_swig_go_result = (MatchResponse *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_MatchResponse_routingkit_32b576f51e679bfa(MatchResponse *_swig_go_0) {
+  MatchResponse *arg1 = (MatchResponse *) 0 ;
+  
+  arg1 = *(MatchResponse **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+intgo _wrap_vehicle_routingkit_32b576f51e679bfa() {
+  transport_mode result;
+  intgo _swig_go_result;
+  
+  
+  result = vehicle;
+  
+  _swig_go_result = (intgo)result; 
+  return _swig_go_result;
+}
+
+
+intgo _wrap_bike_routingkit_32b576f51e679bfa() {
+  transport_mode result;
+  intgo _swig_go_result;
+  
+  
+  result = bike;
+  
+  _swig_go_result = (intgo)result; 
+  return _swig_go_result;
+}
+
+
+intgo _wrap_pedestrian_routingkit_32b576f51e679bfa() {
+  transport_mode result;
+  intgo _swig_go_result;
+  
+  
+  result = pedestrian;
+  
+  _swig_go_result = (intgo)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_Profile_allowedWayIds_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, std::vector< int > *_swig_go_1) {
+  Profile *arg1 = (Profile *) 0 ;
+  std::vector< int > *arg2 = (std::vector< int > *) 0 ;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  arg2 = *(std::vector< int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->allowedWayIds = *arg2;
+  
+}
+
+
+std::vector< int > *_wrap_Profile_allowedWayIds_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
+  Profile *arg1 = (Profile *) 0 ;
+  std::vector< int > *result = 0 ;
+  std::vector< int > *_swig_go_result;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  result = (std::vector< int > *)& ((arg1)->allowedWayIds);
+  *(std::vector< int > **)&_swig_go_result = (std::vector< int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_Profile_waySpeeds_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, std::map< uint64_t,unsigned int,std::less< uint64_t > > *_swig_go_1) {
+  Profile *arg1 = (Profile *) 0 ;
+  std::map< uint64_t,unsigned int,std::less< uint64_t > > *arg2 = (std::map< uint64_t,unsigned int,std::less< uint64_t > > *) 0 ;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  arg2 = *(std::map< uint64_t,unsigned int,std::less< uint64_t > > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->waySpeeds = *arg2;
+  
+}
+
+
+std::map< uint64_t,unsigned int,std::less< uint64_t > > *_wrap_Profile_waySpeeds_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
+  Profile *arg1 = (Profile *) 0 ;
+  std::map< uint64_t,unsigned int,std::less< uint64_t > > *result = 0 ;
+  std::map< uint64_t,unsigned int,std::less< uint64_t > > *_swig_go_result;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  result = (std::map< uint64_t,unsigned int,std::less< uint64_t > > *)& ((arg1)->waySpeeds);
+  *(std::map< uint64_t,unsigned int,std::less< uint64_t > > **)&_swig_go_result = (std::map< uint64_t,unsigned int,std::less< uint64_t > > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_Profile_transportMode_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, intgo _swig_go_1) {
+  Profile *arg1 = (Profile *) 0 ;
+  transport_mode arg2 ;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  arg2 = (transport_mode)_swig_go_1; 
+  
+  if (arg1) (arg1)->transportMode = arg2;
+  
+}
+
+
+intgo _wrap_Profile_transportMode_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
+  Profile *arg1 = (Profile *) 0 ;
+  transport_mode result;
+  intgo _swig_go_result;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  result = (transport_mode) ((arg1)->transportMode);
+  _swig_go_result = (intgo)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_Profile_name_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, _gostring_ _swig_go_1) {
+  Profile *arg1 = (Profile *) 0 ;
+  char *arg2 = (char *) 0 ;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  arg2 = (char *)malloc(_swig_go_1.n + 1);
+  memcpy(arg2, _swig_go_1.p, _swig_go_1.n);
+  arg2[_swig_go_1.n] = '\0';
+  
+  
+  {
+    if (arg2) {
+      arg1->name = (char const *) (new char[strlen((const char *)arg2)+1]);
+      strcpy((char *)arg1->name, (const char *)arg2);
+    } else {
+      arg1->name = 0;
+    }
+  }
+  
+  free(arg2); 
+}
+
+
+_gostring_ _wrap_Profile_name_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
+  Profile *arg1 = (Profile *) 0 ;
+  char *result = 0 ;
+  _gostring_ _swig_go_result;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  result = (char *) ((arg1)->name);
+  _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
+  return _swig_go_result;
+}
+
+
+void _wrap_Profile_prevent_left_turns_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, bool _swig_go_1) {
+  Profile *arg1 = (Profile *) 0 ;
+  bool arg2 ;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  arg2 = (bool)_swig_go_1; 
+  
+  if (arg1) (arg1)->prevent_left_turns = arg2;
+  
+}
+
+
+bool _wrap_Profile_prevent_left_turns_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
+  Profile *arg1 = (Profile *) 0 ;
+  bool result;
+  bool _swig_go_result;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  result = (bool) ((arg1)->prevent_left_turns);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_Profile_prevent_u_turns_set_routingkit_32b576f51e679bfa(Profile *_swig_go_0, bool _swig_go_1) {
+  Profile *arg1 = (Profile *) 0 ;
+  bool arg2 ;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  arg2 = (bool)_swig_go_1; 
+  
+  if (arg1) (arg1)->prevent_u_turns = arg2;
+  
+}
+
+
+bool _wrap_Profile_prevent_u_turns_get_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
+  Profile *arg1 = (Profile *) 0 ;
+  bool result;
+  bool _swig_go_result;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  result = (bool) ((arg1)->prevent_u_turns);
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+Profile *_wrap_new_Profile_routingkit_32b576f51e679bfa() {
+  Profile *result = 0 ;
+  Profile *_swig_go_result;
+  
+  
+  result = (Profile *)new Profile();
+  *(Profile **)&_swig_go_result = (Profile *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_Profile_routingkit_32b576f51e679bfa(Profile *_swig_go_0) {
+  Profile *arg1 = (Profile *) 0 ;
+  
+  arg1 = *(Profile **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+intgo _wrap_max_distance_get_routingkit_32b576f51e679bfa() {
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  
+  result = (unsigned int)(unsigned int)GoRoutingKit::max_distance;
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+intgo _wrap_invalid_arc_get_routingkit_32b576f51e679bfa() {
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  
+  result = (unsigned int)(unsigned int)GoRoutingKit::invalid_arc;
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_install_crash_handlers_routingkit_32b576f51e679bfa(intgo _swig_go_0, intgo _swig_go_1, long long _swig_go_2, long long _swig_go_3) {
+  int arg1 ;
+  int arg2 ;
+  unsigned long long arg3 ;
+  unsigned long long arg4 ;
+  
+  arg1 = (int)_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (unsigned long long)_swig_go_2; 
+  arg4 = (unsigned long long)_swig_go_3; 
+  
+  GoRoutingKit::install_crash_handlers(arg1, arg2, arg3, arg4);
+  
+}
+
+
+void _wrap_RoutingGraph_first_out_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->first_out = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_RoutingGraph_first_out_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->first_out);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_head_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->head = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_RoutingGraph_head_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->head);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_travel_time_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->travel_time = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_RoutingGraph_travel_time_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->travel_time);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_geo_distance_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->geo_distance = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_RoutingGraph_geo_distance_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->geo_distance);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_latitude_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< float > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< float > *arg2 = (std::vector< float > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< float > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->latitude = *arg2;
+  
+}
+
+
+std::vector< float > *_wrap_RoutingGraph_latitude_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< float > *result = 0 ;
+  std::vector< float > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< float > *)& ((arg1)->latitude);
+  *(std::vector< float > **)&_swig_go_result = (std::vector< float > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_longitude_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< float > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< float > *arg2 = (std::vector< float > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< float > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->longitude = *arg2;
+  
+}
+
+
+std::vector< float > *_wrap_RoutingGraph_longitude_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< float > *result = 0 ;
+  std::vector< float > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< float > *)& ((arg1)->longitude);
+  *(std::vector< float > **)&_swig_go_result = (std::vector< float > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_forbidden_turn_from_arc_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->forbidden_turn_from_arc = *arg2;
+  
+}
+
+
+std::vector< unsigned int > *_wrap_RoutingGraph_forbidden_turn_from_arc_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->forbidden_turn_from_arc);
   *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_geo_distance_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
+void _wrap_RoutingGraph_forbidden_turn_to_arc_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
   GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
   std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
   
   arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
   arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
   
-  if (arg1) (arg1)->geo_distance = *arg2;
+  if (arg1) (arg1)->forbidden_turn_to_arc = *arg2;
   
 }
 
 
-std::vector< unsigned int > *_wrap_RoutingGraph_geo_distance_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+std::vector< unsigned int > *_wrap_RoutingGraph_forbidden_turn_to_arc_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
   GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
   std::vector< unsigned int > *result = 0 ;
   std::vector< unsigned int > *_swig_go_result;
   
   arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
   
-  result = (std::vector< unsigned int > *)& ((arg1)->geo_distance);
+  result = (std::vector< unsigned int > *)& ((arg1)->forbidden_turn_to_arc);
   *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_latitude_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< float > *_swig_go_1) {
+void _wrap_RoutingGraph_way_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
   GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< float > *arg2 = (std::vector< float > *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
   
   arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
-  arg2 = *(std::vector< float > **)&_swig_go_1; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
   
-  if (arg1) (arg1)->latitude = *arg2;
+  if (arg1) (arg1)->way = *arg2;
   
 }
 
 
-std::vector< float > *_wrap_RoutingGraph_latitude_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+std::vector< unsigned int > *_wrap_RoutingGraph_way_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
   GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< float > *result = 0 ;
-  std::vector< float > *_swig_go_result;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
   
   arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
   
-  result = (std::vector< float > *)& ((arg1)->latitude);
-  *(std::vector< float > **)&_swig_go_result = (std::vector< float > *)result; 
+  result = (std::vector< unsigned int > *)& ((arg1)->way);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_longitude_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< float > *_swig_go_1) {
+void _wrap_RoutingGraph_way_speed_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
   GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< float > *arg2 = (std::vector< float > *) 0 ;
+  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
   
   arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
-  arg2 = *(std::vector< float > **)&_swig_go_1; 
+  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
   
-  if (arg1) (arg1)->longitude = *arg2;
+  if (arg1) (arg1)->way_speed = *arg2;
   
 }
 
 
-std::vector< float > *_wrap_RoutingGraph_longitude_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+std::vector< unsigned int > *_wrap_RoutingGraph_way_speed_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
   GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< float > *result = 0 ;
-  std::vector< float > *_swig_go_result;
+  std::vector< unsigned int > *result = 0 ;
+  std::vector< unsigned int > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned int > *)& ((arg1)->way_speed);
+  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_first_modelling_byte_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned long long > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned long long > *arg2 = (std::vector< unsigned long long > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned long long > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->first_modelling_byte = *arg2;
+  
+}
+
+
+std::vector< unsigned long long > *_wrap_RoutingGraph_first_modelling_byte_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned long long > *result = 0 ;
+  std::vector< unsigned long long > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned long long > *)& ((arg1)->first_modelling_byte);
+  *(std::vector< unsigned long long > **)&_swig_go_result = (std::vector< unsigned long long > *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_RoutingGraph_modelling_bytes_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned char > *_swig_go_1) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned char > *arg2 = (std::vector< unsigned char > *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg2 = *(std::vector< unsigned char > **)&_swig_go_1; 
+  
+  if (arg1) (arg1)->modelling_bytes = *arg2;
+  
+}
+
+
+std::vector< unsigned char > *_wrap_RoutingGraph_modelling_bytes_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  std::vector< unsigned char > *result = 0 ;
+  std::vector< unsigned char > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (std::vector< unsigned char > *)& ((arg1)->modelling_bytes);
+  *(std::vector< unsigned char > **)&_swig_go_result = (std::vector< unsigned char > *)result; 
+  return _swig_go_result;
+}
+
+
+intgo _wrap_RoutingGraph_node_count_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (unsigned int)((GoRoutingKit::RoutingGraph const *)arg1)->node_count();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+intgo _wrap_RoutingGraph_arc_count_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  result = (unsigned int)((GoRoutingKit::RoutingGraph const *)arg1)->arc_count();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+GoRoutingKit::RoutingGraph *_wrap_new_RoutingGraph_routingkit_32b576f51e679bfa() {
+  GoRoutingKit::RoutingGraph *result = 0 ;
+  GoRoutingKit::RoutingGraph *_swig_go_result;
+  
+  
+  result = (GoRoutingKit::RoutingGraph *)new GoRoutingKit::RoutingGraph();
+  *(GoRoutingKit::RoutingGraph **)&_swig_go_result = (GoRoutingKit::RoutingGraph *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_RoutingGraph_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
+  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+GoRoutingKit::RoadNetwork *_wrap_new_RoadNetwork_routingkit_32b576f51e679bfa(_gostring_ _swig_go_0, Profile *_swig_go_1, bool _swig_go_2) {
+  char *arg1 = (char *) 0 ;
+  Profile arg2 ;
+  bool arg3 ;
+  Profile *argp2 ;
+  GoRoutingKit::RoadNetwork *result = 0 ;
+  GoRoutingKit::RoadNetwork *_swig_go_result;
+  
+  
+  arg1 = (char *)malloc(_swig_go_0.n + 1);
+  memcpy(arg1, _swig_go_0.p, _swig_go_0.n);
+  arg1[_swig_go_0.n] = '\0';
+  
+  
+  argp2 = (Profile *)_swig_go_1;
+  if (argp2 == NULL) {
+    _swig_gopanic("Attempt to dereference null Profile");
+  }
+  arg2 = (Profile)*argp2;
+  
+  arg3 = (bool)_swig_go_2; 
+  
+  result = (GoRoutingKit::RoadNetwork *)new GoRoutingKit::RoadNetwork(arg1,arg2,arg3);
+  *(GoRoutingKit::RoadNetwork **)&_swig_go_result = (GoRoutingKit::RoadNetwork *)result; 
+  free(arg1); 
+  return _swig_go_result;
+}
+
+
+_gostring_ _wrap_RoadNetwork_load_error_routingkit_32b576f51e679bfa(GoRoutingKit::RoadNetwork *_swig_go_0) {
+  GoRoutingKit::RoadNetwork *arg1 = (GoRoutingKit::RoadNetwork *) 0 ;
+  char *result = 0 ;
+  _gostring_ _swig_go_result;
+  
+  arg1 = *(GoRoutingKit::RoadNetwork **)&_swig_go_0; 
+  
+  result = (char *)((GoRoutingKit::RoadNetwork const *)arg1)->load_error();
+  _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_RoadNetwork_routingkit_32b576f51e679bfa(GoRoutingKit::RoadNetwork *_swig_go_0) {
+  GoRoutingKit::RoadNetwork *arg1 = (GoRoutingKit::RoadNetwork *) 0 ;
+  
+  arg1 = *(GoRoutingKit::RoadNetwork **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+intgo _wrap_TargetBuckets_target_count_routingkit_32b576f51e679bfa(GoRoutingKit::TargetBuckets *_swig_go_0) {
+  GoRoutingKit::TargetBuckets *arg1 = (GoRoutingKit::TargetBuckets *) 0 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(GoRoutingKit::TargetBuckets **)&_swig_go_0; 
+  
+  result = (unsigned int)((GoRoutingKit::TargetBuckets const *)arg1)->target_count();
+  _swig_go_result = result; 
+  return _swig_go_result;
+}
+
+
+GoRoutingKit::TargetBuckets *_wrap_new_TargetBuckets_routingkit_32b576f51e679bfa() {
+  GoRoutingKit::TargetBuckets *result = 0 ;
+  GoRoutingKit::TargetBuckets *_swig_go_result;
+  
+  
+  result = (GoRoutingKit::TargetBuckets *)new GoRoutingKit::TargetBuckets();
+  *(GoRoutingKit::TargetBuckets **)&_swig_go_result = (GoRoutingKit::TargetBuckets *)result; 
+  return _swig_go_result;
+}
+
+
+void _wrap_delete_TargetBuckets_routingkit_32b576f51e679bfa(GoRoutingKit::TargetBuckets *_swig_go_0) {
+  GoRoutingKit::TargetBuckets *arg1 = (GoRoutingKit::TargetBuckets *) 0 ;
+  
+  arg1 = *(GoRoutingKit::TargetBuckets **)&_swig_go_0; 
+  
+  delete arg1;
+  
+}
+
+
+QueryResponse *_wrap_Client_query_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, float _swig_go_3, float _swig_go_4, float _swig_go_5, float _swig_go_6, float _swig_go_7, float _swig_go_8, float _swig_go_9, float _swig_go_10, bool _swig_go_11, bool _swig_go_12) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  float arg3 ;
+  float arg4 ;
+  float arg5 ;
+  float arg6 ;
+  float arg7 ;
+  float arg8 ;
+  float arg9 ;
+  float arg10 ;
+  float arg11 ;
+  bool arg12 ;
+  bool arg13 ;
+  QueryResponse result;
+  QueryResponse *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
+  arg5 = (float)_swig_go_4; 
+  arg6 = (float)_swig_go_5; 
+  arg7 = (float)_swig_go_6; 
+  arg8 = (float)_swig_go_7; 
+  arg9 = (float)_swig_go_8; 
+  arg10 = (float)_swig_go_9; 
+  arg11 = (float)_swig_go_10; 
+  arg12 = (bool)_swig_go_11; 
+  arg13 = (bool)_swig_go_12; 
+  
+  result = (arg1)->query(arg2,arg3,arg4,arg5,arg6,arg7,arg8,arg9,arg10,arg11,arg12,arg13);
+  *(QueryResponse **)&_swig_go_result = new QueryResponse(result); 
+  return _swig_go_result;
+}
+
+
+QueryResponse *_wrap_Client_continue_query_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, intgo _swig_go_3, float _swig_go_4, float _swig_go_5, float _swig_go_6, bool _swig_go_7, bool _swig_go_8) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  float arg3 ;
+  unsigned int arg4 ;
+  float arg5 ;
+  float arg6 ;
+  float arg7 ;
+  bool arg8 ;
+  bool arg9 ;
+  QueryResponse result;
+  QueryResponse *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  arg4 = (unsigned int)_swig_go_3; 
+  arg5 = (float)_swig_go_4; 
+  arg6 = (float)_swig_go_5; 
+  arg7 = (float)_swig_go_6; 
+  arg8 = (bool)_swig_go_7; 
+  arg9 = (bool)_swig_go_8; 
+  
+  result = (arg1)->continue_query(arg2,arg3,arg4,arg5,arg6,arg7,arg8,arg9);
+  *(QueryResponse **)&_swig_go_result = new QueryResponse(result); 
+  return _swig_go_result;
+}
+
+
+std::vector< QueryResponse > *_wrap_Client_alternatives_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, float _swig_go_3, float _swig_go_4, float _swig_go_5, float _swig_go_6, intgo _swig_go_7, float _swig_go_8, float _swig_go_9, bool _swig_go_10, bool _swig_go_11) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  float arg3 ;
+  float arg4 ;
+  float arg5 ;
+  float arg6 ;
+  float arg7 ;
+  unsigned int arg8 ;
+  float arg9 ;
+  float arg10 ;
+  bool arg11 ;
+  bool arg12 ;
+  std::vector< QueryResponse > result;
+  std::vector< QueryResponse > *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
+  arg5 = (float)_swig_go_4; 
+  arg6 = (float)_swig_go_5; 
+  arg7 = (float)_swig_go_6; 
+  arg8 = (unsigned int)_swig_go_7; 
+  arg9 = (float)_swig_go_8; 
+  arg10 = (float)_swig_go_9; 
+  arg11 = (bool)_swig_go_10; 
+  arg12 = (bool)_swig_go_11; 
+  
+  result = (arg1)->alternatives(arg2,arg3,arg4,arg5,arg6,arg7,arg8,arg9,arg10,arg11,arg12);
+  *(std::vector< QueryResponse > **)&_swig_go_result = new std::vector< QueryResponse >(result); 
+  return _swig_go_result;
+}
+
+
+intgo _wrap_Client_many_to_many_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, std::vector< int > *_swig_go_1, float _swig_go_2, std::vector< Point > *_swig_go_3, std::vector< Point > *_swig_go_4, unsigned int *_swig_go_5, int *_swig_go_6, unsigned int *_swig_go_7, unsigned int *_swig_go_8, int *_swig_go_9) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  std::vector< int > arg2 ;
+  float arg3 ;
+  std::vector< Point > arg4 ;
+  std::vector< Point > arg5 ;
+  unsigned int *arg6 = (unsigned int *) 0 ;
+  int *arg7 = (int *) 0 ;
+  unsigned int *arg8 = (unsigned int *) 0 ;
+  unsigned int *arg9 = (unsigned int *) 0 ;
+  int *arg10 = (int *) 0 ;
+  std::vector< int > *argp2 ;
+  std::vector< Point > *argp4 ;
+  std::vector< Point > *argp5 ;
+  unsigned int result;
+  intgo _swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  
+  argp2 = (std::vector< int > *)_swig_go_1;
+  if (argp2 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< int >");
+  }
+  arg2 = (std::vector< int >)*argp2;
+  
+  arg3 = (float)_swig_go_2; 
+  
+  argp4 = (std::vector< Point > *)_swig_go_3;
+  if (argp4 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg4 = (std::vector< Point >)*argp4;
+  
+  
+  argp5 = (std::vector< Point > *)_swig_go_4;
+  if (argp5 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg5 = (std::vector< Point >)*argp5;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg6 = *(unsigned int **)&_swig_go_5; 
+  arg7 = *(int **)&_swig_go_6; 
+  arg8 = *(unsigned int **)&_swig_go_7; 
+  arg9 = *(unsigned int **)&_swig_go_8; 
+  arg10 = *(int **)&_swig_go_9; 
   
-  result = (std::vector< float > *)& ((arg1)->longitude);
-  *(std::vector< float > **)&_swig_go_result = (std::vector< float > *)result; 
+  result = (unsigned int)(arg1)->many_to_many(arg2,arg3,arg4,arg5,arg6,arg7,arg8,arg9,arg10);
+  _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_forbidden_turn_from_arc_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+GoRoutingKit::TargetBuckets *_wrap_Client_target_buckets_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, std::vector< int > *_swig_go_1, float _swig_go_2, std::vector< Point > *_swig_go_3, intgo _swig_go_4) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  std::vector< int > arg2 ;
+  float arg3 ;
+  std::vector< Point > arg4 ;
+  unsigned int arg5 ;
+  std::vector< int > *argp2 ;
+  std::vector< Point > *argp4 ;
+  GoRoutingKit::TargetBuckets result;
+  GoRoutingKit::TargetBuckets *_swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
-  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
   
-  if (arg1) (arg1)->forbidden_turn_from_arc = *arg2;
+  argp2 = (std::vector< int > *)_swig_go_1;
+  if (argp2 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< int >");
+  }
+  arg2 = (std::vector< int >)*argp2;
+  
+  arg3 = (float)_swig_go_2; 
+  
+  argp4 = (std::vector< Point > *)_swig_go_3;
+  if (argp4 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg4 = (std::vector< Point >)*argp4;
+  
+  arg5 = (unsigned int)_swig_go_4; 
   
+  result = (arg1)->target_buckets(arg2,arg3,arg4,arg5);
+  *(GoRoutingKit::TargetBuckets **)&_swig_go_result = new GoRoutingKit::TargetBuckets(result); 
+  return _swig_go_result;
 }
 
 
-std::vector< unsigned int > *_wrap_RoutingGraph_forbidden_turn_from_arc_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *result = 0 ;
-  std::vector< unsigned int > *_swig_go_result;
+intgo _wrap_Client_many_to_many_rows_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, std::vector< int > *_swig_go_1, GoRoutingKit::TargetBuckets *_swig_go_2, float _swig_go_3, std::vector< Point > *_swig_go_4, unsigned int *_swig_go_5, int *_swig_go_6, unsigned int *_swig_go_7, unsigned int *_swig_go_8, int *_swig_go_9) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  std::vector< int > arg2 ;
+  GoRoutingKit::TargetBuckets *arg3 = 0 ;
+  float arg4 ;
+  std::vector< Point > arg5 ;
+  unsigned int *arg6 = (unsigned int *) 0 ;
+  int *arg7 = (int *) 0 ;
+  unsigned int *arg8 = (unsigned int *) 0 ;
+  unsigned int *arg9 = (unsigned int *) 0 ;
+  int *arg10 = (int *) 0 ;
+  std::vector< int > *argp2 ;
+  std::vector< Point > *argp5 ;
+  unsigned int result;
+  intgo _swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
   
-  result = (std::vector< unsigned int > *)& ((arg1)->forbidden_turn_from_arc);
-  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  argp2 = (std::vector< int > *)_swig_go_1;
+  if (argp2 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< int >");
+  }
+  arg2 = (std::vector< int >)*argp2;
+  
+  arg3 = *(GoRoutingKit::TargetBuckets **)&_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
+  
+  argp5 = (std::vector< Point > *)_swig_go_4;
+  if (argp5 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg5 = (std::vector< Point >)*argp5;
+  
+  arg6 = *(unsigned int **)&_swig_go_5; 
+  arg7 = *(int **)&_swig_go_6; 
+  arg8 = *(unsigned int **)&_swig_go_7; 
+  arg9 = *(unsigned int **)&_swig_go_8; 
+  arg10 = *(int **)&_swig_go_9; 
+  
+  result = (unsigned int)(arg1)->many_to_many_rows(arg2,(GoRoutingKit::TargetBuckets const &)*arg3,arg4,arg5,arg6,arg7,arg8,arg9,arg10);
+  _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_RoutingGraph_forbidden_turn_to_arc_set_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0, std::vector< unsigned int > *_swig_go_1) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *arg2 = (std::vector< unsigned int > *) 0 ;
+std::vector< unsigned int > *_wrap_Client_sparse_many_to_many_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, std::vector< int > *_swig_go_1, GoRoutingKit::TargetBuckets *_swig_go_2, float _swig_go_3, std::vector< Point > *_swig_go_4, intgo _swig_go_5, int *_swig_go_6) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  std::vector< int > arg2 ;
+  GoRoutingKit::TargetBuckets *arg3 = 0 ;
+  float arg4 ;
+  std::vector< Point > arg5 ;
+  unsigned int arg6 ;
+  int *arg7 = (int *) 0 ;
+  std::vector< int > *argp2 ;
+  std::vector< Point > *argp5 ;
+  std::vector< unsigned int > result;
+  std::vector< unsigned int > *_swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
-  arg2 = *(std::vector< unsigned int > **)&_swig_go_1; 
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
   
-  if (arg1) (arg1)->forbidden_turn_to_arc = *arg2;
+  argp2 = (std::vector< int > *)_swig_go_1;
+  if (argp2 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< int >");
+  }
+  arg2 = (std::vector< int >)*argp2;
+  
+  arg3 = *(GoRoutingKit::TargetBuckets **)&_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
+  
+  argp5 = (std::vector< Point > *)_swig_go_4;
+  if (argp5 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg5 = (std::vector< Point >)*argp5;
+  
+  arg6 = (unsigned int)_swig_go_5; 
+  arg7 = *(int **)&_swig_go_6; 
   
+  result = (arg1)->sparse_many_to_many(arg2,(GoRoutingKit::TargetBuckets const &)*arg3,arg4,arg5,arg6,arg7);
+  *(std::vector< unsigned int > **)&_swig_go_result = new std::vector< unsigned int >(result); 
+  return _swig_go_result;
 }
 
 
-std::vector< unsigned int > *_wrap_RoutingGraph_forbidden_turn_to_arc_get_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  std::vector< unsigned int > *result = 0 ;
+std::vector< unsigned int > *_wrap_Client_nearest_targets_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, GoRoutingKit::TargetBuckets *_swig_go_2, float _swig_go_3, Point *_swig_go_4, intgo _swig_go_5, intgo _swig_go_6) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  GoRoutingKit::TargetBuckets *arg3 = 0 ;
+  float arg4 ;
+  Point arg5 ;
+  unsigned int arg6 ;
+  unsigned int arg7 ;
+  Point *argp5 ;
+  std::vector< unsigned int > result;
   std::vector< unsigned int > *_swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = *(GoRoutingKit::TargetBuckets **)&_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
   
-  result = (std::vector< unsigned int > *)& ((arg1)->forbidden_turn_to_arc);
-  *(std::vector< unsigned int > **)&_swig_go_result = (std::vector< unsigned int > *)result; 
+  argp5 = (Point *)_swig_go_4;
+  if (argp5 == NULL) {
+    _swig_gopanic("Attempt to dereference null Point");
+  }
+  arg5 = (Point)*argp5;
+  
+  arg6 = (unsigned int)_swig_go_5; 
+  arg7 = (unsigned int)_swig_go_6; 
+  
+  result = (arg1)->nearest_targets(arg2,(GoRoutingKit::TargetBuckets const &)*arg3,arg4,arg5,arg6,arg7);
+  *(std::vector< unsigned int > **)&_swig_go_result = new std::vector< unsigned int >(result); 
   return _swig_go_result;
 }
 
 
-intgo _wrap_RoutingGraph_node_count_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+intgo _wrap_Client_node_count_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
   unsigned int result;
   intgo _swig_go_result;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
   
-  result = (unsigned int)((GoRoutingKit::RoutingGraph const *)arg1)->node_count();
+  result = (unsigned int)((GoRoutingKit::Client const *)arg1)->node_count();
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-intgo _wrap_RoutingGraph_arc_count_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
-  unsigned int result;
-  intgo _swig_go_result;
+void _wrap_Client_node_positions_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, float *_swig_go_1, float *_swig_go_2) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  float *arg2 = (float *) 0 ;
+  float *arg3 = (float *) 0 ;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = *(float **)&_swig_go_1; 
+  arg3 = *(float **)&_swig_go_2; 
+  
+  ((GoRoutingKit::Client const *)arg1)->node_positions(arg2,arg3);
   
-  result = (unsigned int)((GoRoutingKit::RoutingGraph const *)arg1)->arc_count();
-  _swig_go_result = result; 
-  return _swig_go_result;
 }
 
 
-GoRoutingKit::RoutingGraph *_wrap_new_RoutingGraph_routingkit_32b576f51e679bfa() {
-  GoRoutingKit::RoutingGraph *result = 0 ;
-  GoRoutingKit::RoutingGraph *_swig_go_result;
+bool _wrap_Client_one_to_all_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, float _swig_go_3, float _swig_go_4, unsigned int *_swig_go_5, int *_swig_go_6) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  float arg3 ;
+  float arg4 ;
+  float arg5 ;
+  unsigned int *arg6 = (unsigned int *) 0 ;
+  int *arg7 = (int *) 0 ;
+  bool result;
+  bool _swig_go_result;
   
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
+  arg5 = (float)_swig_go_4; 
+  arg6 = *(unsigned int **)&_swig_go_5; 
+  arg7 = *(int **)&_swig_go_6; 
   
-  result = (GoRoutingKit::RoutingGraph *)new GoRoutingKit::RoutingGraph();
-  *(GoRoutingKit::RoutingGraph **)&_swig_go_result = (GoRoutingKit::RoutingGraph *)result; 
+  result = (bool)(arg1)->one_to_all(arg2,arg3,arg4,arg5,arg6,arg7);
+  _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_delete_RoutingGraph_routingkit_32b576f51e679bfa(GoRoutingKit::RoutingGraph *_swig_go_0) {
-  GoRoutingKit::RoutingGraph *arg1 = (GoRoutingKit::RoutingGraph *) 0 ;
+void _wrap_Client_sample_costs_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, float _swig_go_1, float _swig_go_2, float _swig_go_3, unsigned int *_swig_go_4, std::vector< Point > *_swig_go_5, unsigned int *_swig_go_6) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  float arg2 ;
+  float arg3 ;
+  float arg4 ;
+  unsigned int *arg5 = (unsigned int *) 0 ;
+  std::vector< Point > arg6 ;
+  unsigned int *arg7 = (unsigned int *) 0 ;
+  std::vector< Point > *argp6 ;
   
-  arg1 = *(GoRoutingKit::RoutingGraph **)&_swig_go_0; 
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (float)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
+  arg5 = *(unsigned int **)&_swig_go_4; 
   
-  delete arg1;
+  argp6 = (std::vector< Point > *)_swig_go_5;
+  if (argp6 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg6 = (std::vector< Point >)*argp6;
+  
+  arg7 = *(unsigned int **)&_swig_go_6; 
+  
+  ((GoRoutingKit::Client const *)arg1)->sample_costs(arg2,arg3,arg4,arg5,arg6,arg7);
   
 }
 
 
-QueryResponse *_wrap_Client_query_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, float _swig_go_3, float _swig_go_4, float _swig_go_5, float _swig_go_6, bool _swig_go_7) {
+ReachResponse *_wrap_Client_reachable_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, float _swig_go_3, float _swig_go_4, intgo _swig_go_5, float _swig_go_6, bool _swig_go_7, int *_swig_go_8) {
   GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
   int arg2 ;
   float arg3 ;
   float arg4 ;
   float arg5 ;
-  float arg6 ;
+  unsigned int arg6 ;
   float arg7 ;
   bool arg8 ;
-  QueryResponse result;
-  QueryResponse *_swig_go_result;
+  int *arg9 = (int *) 0 ;
+  ReachResponse result;
+  ReachResponse *_swig_go_result;
   
   arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
   arg2 = (int)_swig_go_1; 
   arg3 = (float)_swig_go_2; 
   arg4 = (float)_swig_go_3; 
   arg5 = (float)_swig_go_4; 
-  arg6 = (float)_swig_go_5; 
+  arg6 = (unsigned int)_swig_go_5; 
   arg7 = (float)_swig_go_6; 
   arg8 = (bool)_swig_go_7; 
+  arg9 = *(int **)&_swig_go_8; 
   
-  result = (arg1)->query(arg2,arg3,arg4,arg5,arg6,arg7,arg8);
-  *(QueryResponse **)&_swig_go_result = new QueryResponse(result); 
+  result = (arg1)->reachable(arg2,arg3,arg4,arg5,arg6,arg7,arg8,arg9);
+  *(ReachResponse **)&_swig_go_result = new ReachResponse(result); 
+  return _swig_go_result;
+}
+
+
+MatchResponse *_wrap_Client_map_match_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, std::vector< Point > *_swig_go_3, std::vector< float > *_swig_go_4, float _swig_go_5, float _swig_go_6, float _swig_go_7, intgo _swig_go_8) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  float arg3 ;
+  std::vector< Point > arg4 ;
+  std::vector< float > arg5 ;
+  float arg6 ;
+  float arg7 ;
+  float arg8 ;
+  unsigned int arg9 ;
+  std::vector< Point > *argp4 ;
+  std::vector< float > *argp5 ;
+  MatchResponse result;
+  MatchResponse *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  
+  argp4 = (std::vector< Point > *)_swig_go_3;
+  if (argp4 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg4 = (std::vector< Point >)*argp4;
+  
+  
+  argp5 = (std::vector< float > *)_swig_go_4;
+  if (argp5 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< float >");
+  }
+  arg5 = (std::vector< float >)*argp5;
+  
+  arg6 = (float)_swig_go_5; 
+  arg7 = (float)_swig_go_6; 
+  arg8 = (float)_swig_go_7; 
+  arg9 = (unsigned int)_swig_go_8; 
+  
+  result = (arg1)->map_match(arg2,arg3,arg4,arg5,arg6,arg7,arg8,arg9);
+  *(MatchResponse **)&_swig_go_result = new MatchResponse(result); 
   return _swig_go_result;
 }
 
 
-std::vector< unsigned int > *_wrap_Client_distances_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, Point *_swig_go_3, std::vector< Point > *_swig_go_4) {
+std::vector< unsigned int > *_wrap_Client_distances_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, Point *_swig_go_3, float _swig_go_4, float _swig_go_5, std::vector< Point > *_swig_go_6, std::vector< float > *_swig_go_7, std::vector< float > *_swig_go_8) {
   GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
   int arg2 ;
   float arg3 ;
   Point arg4 ;
-  std::vector< Point > arg5 ;
+  float arg5 ;
+  float arg6 ;
+  std::vector< Point > arg7 ;
+  std::vector< float > arg8 ;
+  std::vector< float > arg9 ;
   Point *argp4 ;
-  std::vector< Point > *argp5 ;
+  std::vector< Point > *argp7 ;
+  std::vector< float > *argp8 ;
+  std::vector< float > *argp9 ;
   std::vector< unsigned int > result;
   std::vector< unsigned int > *_swig_go_result;
   
@@ -1997,6 +4126,58 @@ std::vector< unsigned int > *_wrap_Client_distances_routingkit_32b576f51e679bfa(
   }
   arg4 = (Point)*argp4;
   
+  arg5 = (float)_swig_go_4; 
+  arg6 = (float)_swig_go_5; 
+  
+  argp7 = (std::vector< Point > *)_swig_go_6;
+  if (argp7 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< Point >");
+  }
+  arg7 = (std::vector< Point >)*argp7;
+  
+  
+  argp8 = (std::vector< float > *)_swig_go_7;
+  if (argp8 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< float >");
+  }
+  arg8 = (std::vector< float >)*argp8;
+  
+  
+  argp9 = (std::vector< float > *)_swig_go_8;
+  if (argp9 == NULL) {
+    _swig_gopanic("Attempt to dereference null std::vector< float >");
+  }
+  arg9 = (std::vector< float >)*argp9;
+  
+  
+  result = (arg1)->distances(arg2,arg3,arg4,arg5,arg6,arg7,arg8,arg9);
+  *(std::vector< unsigned int > **)&_swig_go_result = new std::vector< unsigned int >(result); 
+  return _swig_go_result;
+}
+
+
+DistancesResponse *_wrap_Client_detailed_distances_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, Point *_swig_go_3, std::vector< Point > *_swig_go_4, bool _swig_go_5) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  float arg3 ;
+  Point arg4 ;
+  std::vector< Point > arg5 ;
+  bool arg6 ;
+  Point *argp4 ;
+  std::vector< Point > *argp5 ;
+  DistancesResponse result;
+  DistancesResponse *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  
+  argp4 = (Point *)_swig_go_3;
+  if (argp4 == NULL) {
+    _swig_gopanic("Attempt to dereference null Point");
+  }
+  arg4 = (Point)*argp4;
+  
   
   argp5 = (std::vector< Point > *)_swig_go_4;
   if (argp5 == NULL) {
@@ -2004,9 +4185,10 @@ std::vector< unsigned int > *_wrap_Client_distances_routingkit_32b576f51e679bfa(
   }
   arg5 = (std::vector< Point >)*argp5;
   
+  arg6 = (bool)_swig_go_5; 
   
-  result = (arg1)->distances(arg2,arg3,arg4,arg5);
-  *(std::vector< unsigned int > **)&_swig_go_result = new std::vector< unsigned int >(result); 
+  result = (arg1)->detailed_distances(arg2,arg3,arg4,arg5,arg6);
+  *(DistancesResponse **)&_swig_go_result = new DistancesResponse(result); 
   return _swig_go_result;
 }
 
@@ -2032,42 +4214,66 @@ Point *_wrap_Client_nearest_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_s
 }
 
 
-GoRoutingKit::Client *_wrap_new_Client_routingkit_32b576f51e679bfa(intgo _swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2, Profile *_swig_go_3) {
+SnapResponse *_wrap_Client_snap_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0, intgo _swig_go_1, float _swig_go_2, float _swig_go_3, float _swig_go_4) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  int arg2 ;
+  float arg3 ;
+  float arg4 ;
+  float arg5 ;
+  SnapResponse result;
+  SnapResponse *_swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  arg2 = (int)_swig_go_1; 
+  arg3 = (float)_swig_go_2; 
+  arg4 = (float)_swig_go_3; 
+  arg5 = (float)_swig_go_4; 
+  
+  result = (arg1)->snap(arg2,arg3,arg4,arg5);
+  *(SnapResponse **)&_swig_go_result = new SnapResponse(result); 
+  return _swig_go_result;
+}
+
+
+GoRoutingKit::Client *_wrap_new_Client_routingkit_32b576f51e679bfa(intgo _swig_go_0, GoRoutingKit::RoadNetwork *_swig_go_1, _gostring_ _swig_go_2, bool _swig_go_3, bool _swig_go_4) {
   int arg1 ;
-  char *arg2 = (char *) 0 ;
+  GoRoutingKit::RoadNetwork *arg2 = (GoRoutingKit::RoadNetwork *) 0 ;
   char *arg3 = (char *) 0 ;
-  Profile arg4 ;
-  Profile *argp4 ;
+  bool arg4 ;
+  bool arg5 ;
   GoRoutingKit::Client *result = 0 ;
   GoRoutingKit::Client *_swig_go_result;
   
   arg1 = (int)_swig_go_0; 
-  
-  arg2 = (char *)malloc(_swig_go_1.n + 1);
-  memcpy(arg2, _swig_go_1.p, _swig_go_1.n);
-  arg2[_swig_go_1.n] = '\0';
-  
+  arg2 = *(GoRoutingKit::RoadNetwork **)&_swig_go_1; 
   
   arg3 = (char *)malloc(_swig_go_2.n + 1);
   memcpy(arg3, _swig_go_2.p, _swig_go_2.n);
   arg3[_swig_go_2.n] = '\0';
   
+  arg4 = (bool)_swig_go_3; 
+  arg5 = (bool)_swig_go_4; 
   
-  argp4 = (Profile *)_swig_go_3;
-  if (argp4 == NULL) {
-    _swig_gopanic("Attempt to dereference null Profile");
-  }
-  arg4 = (Profile)*argp4;
-  
-  
-  result = (GoRoutingKit::Client *)new GoRoutingKit::Client(arg1,arg2,arg3,arg4);
+  result = (GoRoutingKit::Client *)new GoRoutingKit::Client(arg1,arg2,arg3,arg4,arg5);
   *(GoRoutingKit::Client **)&_swig_go_result = (GoRoutingKit::Client *)result; 
-  free(arg2); 
   free(arg3); 
   return _swig_go_result;
 }
 
 
+_gostring_ _wrap_Client_load_error_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0) {
+  GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
+  char *result = 0 ;
+  _gostring_ _swig_go_result;
+  
+  arg1 = *(GoRoutingKit::Client **)&_swig_go_0; 
+  
+  result = (char *)((GoRoutingKit::Client const *)arg1)->load_error();
+  _swig_go_result = Swig_AllocateString((char*)result, result ? strlen((char*)result) : 0); 
+  return _swig_go_result;
+}
+
+
 void _wrap_delete_Client_routingkit_32b576f51e679bfa(GoRoutingKit::Client *_swig_go_0) {
   GoRoutingKit::Client *arg1 = (GoRoutingKit::Client *) 0 ;
   
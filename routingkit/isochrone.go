@@ -0,0 +1,319 @@
+package routingkit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync/atomic"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// Feature is a GeoJSON feature.
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry is a GeoJSON MultiPolygon geometry. Coordinates holds, for every
+// polygon, its exterior ring followed by its holes. A ring is a closed list of
+// [longitude, latitude] positions, counterclockwise for exterior rings and
+// clockwise for holes.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates [][][][]float64 `json:"coordinates"`
+}
+
+// FeatureCollection is a GeoJSON feature collection.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewFeatureCollection returns a GeoJSON feature collection of the features.
+func NewFeatureCollection(features []Feature) FeatureCollection {
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// Isochrone returns the areas reachable from the center within each of the
+// limits, as one GeoJSON feature per limit in the order of the limits. All
+// areas are computed with a single search on the road network up to the
+// largest limit. An area is made of the grid cells, sized as set by
+// WithIsochroneCellSize, that contain a part of a road reachable within the
+// limit. The geometry of a feature is a MultiPolygon, since an area may
+// consist of several parts, and its "limit" property holds the limit.
+//
+// The returned bool is false if the center cannot be snapped, in which case
+// all areas are empty. Isochrone returns an error if the center is invalid or
+// if there are no limits, and ctx.Err() if ctx is done before a free query
+// slot becomes available or before the search is complete.
+func (c client) Isochrone(ctx context.Context, center Point, limits []uint32) ([]Feature, bool, error) {
+	return c.isochrone(ctx, center, limits, false)
+}
+
+// InboundIsochrone is like Isochrone, but returns the areas from which the
+// center can be reached within each of the limits. The search runs on the
+// reversed road network, so the areas differ from those returned by Isochrone
+// where roads are one-way.
+func (c client) InboundIsochrone(ctx context.Context, center Point, limits []uint32) ([]Feature, bool, error) {
+	return c.isochrone(ctx, center, limits, true)
+}
+
+// isochrone computes the areas reachable from the center within the limits or,
+// if inbound is set, the areas from which the center is reachable.
+func (c client) isochrone(ctx context.Context, center Point, limits []uint32, inbound bool) ([]Feature, bool, error) {
+	if err := center.Validate(); err != nil {
+		return nil, false, err
+	}
+	if len(limits) == 0 {
+		return nil, false, fmt.Errorf("an isochrone needs at least one limit")
+	}
+	var maxLimit uint32
+	for _, limit := range limits {
+		if limit > maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	cancelled, stop := cancellation(ctx)
+	resp := c.client.Reachable(counter, c.snapRadius, center.Lon, center.Lat, uint(maxLimit), c.cellSize/2, inbound, intBuffer(cancelled))
+	stop()
+	runtime.KeepAlive(cancelled)
+	points := toPoints(resp.GetPoints())
+	costs := toUint32s(resp.GetCosts())
+	routingkit.DeleteReachResponse(resp)
+	c.release(counter)
+	if atomic.LoadInt32(&cancelled[0]) != 0 {
+		return nil, false, ctx.Err()
+	}
+
+	// the lowest cost at which each cell is reached
+	g := newGrid(center, c.cellSize)
+	cellCosts := map[vertex]uint32{}
+	for i, p := range points {
+		k := g.cell(p)
+		if cost, ok := cellCosts[k]; !ok || costs[i] < cost {
+			cellCosts[k] = costs[i]
+		}
+	}
+
+	features := make([]Feature, len(limits))
+	for i, limit := range limits {
+		cells := map[vertex]bool{}
+		for k, cost := range cellCosts {
+			if cost <= limit {
+				cells[k] = true
+			}
+		}
+		coordinates := [][][][]float64{}
+		for _, polygon := range contour(cells) {
+			rings := make([][][]float64, len(polygon))
+			for r, ring := range polygon {
+				rings[r] = make([][]float64, len(ring))
+				for v, corner := range ring {
+					rings[r][v] = g.position(corner)
+				}
+			}
+			coordinates = append(coordinates, rings)
+		}
+		features[i] = Feature{
+			Type:       "Feature",
+			Geometry:   Geometry{Type: "MultiPolygon", Coordinates: coordinates},
+			Properties: map[string]interface{}{"limit": limit},
+		}
+	}
+	return features, len(points) > 0, nil
+}
+
+// metersPerDegree is the length in meters of a degree of latitude.
+const metersPerDegree = 111111
+
+// vertex is a position on a grid: the column and row of a cell, or of its
+// lower left corner.
+type vertex struct {
+	x, y int
+}
+
+// grid divides the plane into square cells of about the given size in meters,
+// with a corner at the origin.
+type grid struct {
+	origin     Point
+	lonPerCell float64
+	latPerCell float64
+}
+
+func newGrid(origin Point, size float32) grid {
+	lat := float64(size) / metersPerDegree
+	return grid{
+		origin:     origin,
+		lonPerCell: lat / math.Cos(float64(origin.Lat)*math.Pi/180),
+		latPerCell: lat,
+	}
+}
+
+// cell returns the cell containing the point.
+func (g grid) cell(p Point) vertex {
+	return vertex{
+		x: int(math.Floor(float64(p.Lon-g.origin.Lon) / g.lonPerCell)),
+		y: int(math.Floor(float64(p.Lat-g.origin.Lat) / g.latPerCell)),
+	}
+}
+
+// position returns the [longitude, latitude] position of the corner.
+func (g grid) position(corner vertex) []float64 {
+	return []float64{
+		float64(g.origin.Lon) + float64(corner.x)*g.lonPerCell,
+		float64(g.origin.Lat) + float64(corner.y)*g.latPerCell,
+	}
+}
+
+// contour traces the outlines of the union of the cells. It returns the
+// polygons covering the cells, each given by its exterior ring followed by its
+// holes. A ring is a closed list of corners, counterclockwise for exterior
+// rings and clockwise for holes. Cells touching only at a corner belong to
+// different polygons.
+func contour(cells map[vertex]bool) [][][]vertex {
+	sorted := make([]vertex, 0, len(cells))
+	for k := range cells {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].y < sorted[j].y || sorted[i].y == sorted[j].y && sorted[i].x < sorted[j].x
+	})
+
+	// the sides of the cells at the border, directed so that the cells are on
+	// their left
+	type edge struct{ from, to vertex }
+	var edges []edge
+	next := map[vertex][]vertex{}
+	add := func(from, to vertex) {
+		edges = append(edges, edge{from, to})
+		next[from] = append(next[from], to)
+	}
+	for _, k := range sorted {
+		x, y := k.x, k.y
+		if !cells[vertex{x, y - 1}] {
+			add(vertex{x, y}, vertex{x + 1, y})
+		}
+		if !cells[vertex{x + 1, y}] {
+			add(vertex{x + 1, y}, vertex{x + 1, y + 1})
+		}
+		if !cells[vertex{x, y + 1}] {
+			add(vertex{x + 1, y + 1}, vertex{x, y + 1})
+		}
+		if !cells[vertex{x - 1, y}] {
+			add(vertex{x, y + 1}, vertex{x, y})
+		}
+	}
+
+	// every edge is followed by the leftmost edge leaving its end, which
+	// separates cells touching only at a corner
+	successor := func(e edge) edge {
+		dx, dy := e.to.x-e.from.x, e.to.y-e.from.y
+		best, bestTurn := edge{}, math.MinInt32
+		for _, to := range next[e.to] {
+			turn := dx*(to.y-e.to.y) - dy*(to.x-e.to.x)
+			if turn > bestTurn {
+				best, bestTurn = edge{e.to, to}, turn
+			}
+		}
+		return best
+	}
+	used := map[edge]bool{}
+	var exteriors, holes [][]vertex
+	for _, start := range edges {
+		if used[start] {
+			continue
+		}
+		var ring []vertex
+		for e := start; !used[e]; e = successor(e) {
+			used[e] = true
+			// only keep the corners at which the outline turns
+			if f := successor(e); f.to.x-f.from.x != e.to.x-e.from.x || f.to.y-f.from.y != e.to.y-e.from.y {
+				ring = append(ring, e.to)
+			}
+		}
+		ring = append(ring, ring[0])
+		if ringArea(ring) > 0 {
+			exteriors = append(exteriors, ring)
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	polygons := make([][][]vertex, len(exteriors))
+	for i, exterior := range exteriors {
+		polygons[i] = [][]vertex{exterior}
+	}
+	for _, hole := range holes {
+		// a point inside a cell next to the hole, which lies within the
+		// smallest exterior ring around the hole
+		a, b := hole[0], hole[1]
+		dx, dy := sign(b.x-a.x), sign(b.y-a.y)
+		x := float64(a.x) + 0.5*float64(dx) - 0.25*float64(dy)
+		y := float64(a.y) + 0.5*float64(dy) + 0.25*float64(dx)
+		best := -1
+		for i, exterior := range exteriors {
+			if contains(exterior, x, y) && (best < 0 || ringArea(exterior) < ringArea(exteriors[best])) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			polygons[best] = append(polygons[best], hole)
+		}
+	}
+	return polygons
+}
+
+// ringArea returns the signed area of the closed ring, which is positive if
+// the ring is counterclockwise.
+func ringArea(ring []vertex) int {
+	area := 0
+	for i := 1; i < len(ring); i++ {
+		area += ring[i-1].x*ring[i].y - ring[i].x*ring[i-1].y
+	}
+	return area / 2
+}
+
+// contains tells whether the point lies within the closed ring. The point
+// must not lie on the ring.
+func contains(ring []vertex, x, y float64) bool {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if (float64(a.y) > y) != (float64(b.y) > y) &&
+			x < float64(a.x)+(y-float64(a.y))*float64(b.x-a.x)/float64(b.y-a.y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+// Isochrone returns the areas reachable from the center within each of the
+// travel time limits, as one GeoJSON feature per limit.
+func (c TravelTimeClient) Isochrone(ctx context.Context, center Point, limits []uint32) ([]Feature, bool, error) {
+	return c.client.Isochrone(ctx, center, limits)
+}
+
+// InboundIsochrone returns the areas from which the center can be reached
+// within each of the travel time limits, as one GeoJSON feature per limit.
+func (c TravelTimeClient) InboundIsochrone(ctx context.Context, center Point, limits []uint32) ([]Feature, bool, error) {
+	return c.client.InboundIsochrone(ctx, center, limits)
+}
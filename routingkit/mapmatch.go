@@ -0,0 +1,127 @@
+package routingkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// TimedPoint is a GPS position together with the time it was measured at.
+type TimedPoint struct {
+	Point
+	Time time.Time
+}
+
+// MatchedPoint is the position on the road network a GPS position was
+// matched to.
+type MatchedPoint struct {
+	// Matched is false if there is no road within the snap radius of the
+	// GPS position, in which case Point is the zero value.
+	Matched bool
+	Point   Point
+	// Confidence is the probability, between 0 and 1, that the GPS position
+	// was measured within the GPS standard deviation set by WithMapMatching
+	// of the matched position, given the whole trace.
+	Confidence float64
+}
+
+// MatchResult is the route a GPS trace was matched to.
+type MatchResult struct {
+	// Points holds the matched position of every GPS position of the trace.
+	Points []MatchedPoint
+	// Path holds the waypoints of the matched route through the matched
+	// positions.
+	Path []Point
+	// Distance is the length of the matched route in meters.
+	Distance uint32
+}
+
+const (
+	// matchCandidates is the number of nearest road segments considered as
+	// the position of every GPS position.
+	matchCandidates = 8
+	// matchMaxSpeed is the speed in meters per second that is never exceeded
+	// between two GPS positions.
+	matchMaxSpeed = 60
+)
+
+// MapMatch matches the GPS trace, ordered by time, to the route on the road
+// network it was most likely measured on, using a hidden Markov model. The
+// candidate positions of every GPS position are its projections onto the
+// nearest roads within the snap radius, in either direction. Candidates far
+// from the GPS position, and routes between candidates that are much longer
+// than the straight distance between the positions or that cannot be driven
+// in the time between them, are unlikely; see WithMapMatching.
+//
+// Positions without any road within the snap radius are left unmatched and do
+// not affect the match of the other positions. If consecutive matched
+// positions cannot be matched to connected roads, the trace is split and
+// matched in parts. The path then jumps from one part to the next, and the
+// distance between the parts is not included in Distance.
+//
+// MapMatch returns an error if a position is invalid, if the trace is not
+// ordered by time or if ctx is done before a free query slot becomes
+// available.
+func (c client) MapMatch(ctx context.Context, trace []TimedPoint) (MatchResult, error) {
+	points := make([]Point, len(trace))
+	seconds := routingkit.NewFloatVector(int64(len(trace)))
+	defer routingkit.DeleteFloatVector(seconds)
+	for i, p := range trace {
+		if err := p.Validate(); err != nil {
+			return MatchResult{}, err
+		}
+		if i > 0 && p.Time.Before(trace[i-1].Time) {
+			return MatchResult{}, fmt.Errorf("trace is not ordered by time at position %d", i)
+		}
+		points[i] = p.Point
+		if i > 0 {
+			seconds.Set(i, float32(p.Time.Sub(trace[0].Time).Seconds()))
+		}
+	}
+
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	defer c.release(counter)
+	pointsVector := toSwigPoints(points)
+	defer routingkit.DeletePointVector(pointsVector)
+	resp := c.client.Map_match(
+		counter,
+		c.snapRadius,
+		pointsVector,
+		seconds,
+		c.gpsSigma,
+		c.gpsBeta,
+		matchMaxSpeed,
+		matchCandidates,
+	)
+	defer routingkit.DeleteMatchResponse(resp)
+
+	matched := toPoints(resp.GetPoints())
+	flags := resp.GetMatched()
+	confidences := resp.GetConfidences()
+	result := MatchResult{
+		Points:   make([]MatchedPoint, len(trace)),
+		Path:     toPoints(resp.GetPath()),
+		Distance: uint32(resp.GetDistance()),
+	}
+	for i := range result.Points {
+		if flags.Get(i) != 0 {
+			result.Points[i] = MatchedPoint{
+				Matched:    true,
+				Point:      matched[i],
+				Confidence: float64(confidences.Get(i)),
+			}
+		}
+	}
+	return result, nil
+}
+
+// MapMatch matches the GPS trace, ordered by time, to the route on the road
+// network it was most likely measured on.
+func (c TravelTimeClient) MapMatch(ctx context.Context, trace []TimedPoint) (MatchResult, error) {
+	return c.client.MapMatch(ctx, trace)
+}
@@ -0,0 +1,124 @@
+package routingkit
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// CostMap holds the costs from a source to all nodes of the road network, as
+// computed by OneToAll.
+type CostMap struct {
+	// Source is the point the costs are computed from.
+	Source Point
+	// Costs holds the cost from the source to every node of the road network,
+	// indexed like the positions returned by Nodes. It is MaxDistance for
+	// nodes that cannot be reached.
+	Costs []uint32
+}
+
+// Nodes returns the position of every node of the road network. The costs of
+// OneToAll are indexed by the same node indices.
+func (c client) Nodes() []Point {
+	n := int(c.client.Node_count())
+	lons := make([]float32, n)
+	lats := make([]float32, n)
+	c.client.Node_positions(floatBuffer(lons), floatBuffer(lats))
+	runtime.KeepAlive(lons)
+	runtime.KeepAlive(lats)
+	nodes := make([]Point, n)
+	for i := range nodes {
+		nodes[i] = Point{Lon: lons[i], Lat: lats[i]}
+	}
+	return nodes
+}
+
+// OneToAll computes the costs from the source to all nodes of the road
+// network. Instead of a search per node, it runs a single upward search from
+// the source followed by a sweep over the contraction hierarchy in node order
+// (PHAST), which takes time linear in the size of the network. The returned
+// bool is false if the source cannot be snapped, in which case all costs are
+// MaxDistance. It returns an error if the source is invalid, and ctx.Err() if
+// ctx is done before a free query slot becomes available or before the sweep
+// is complete.
+func (c client) OneToAll(ctx context.Context, source Point) (CostMap, bool, error) {
+	if err := source.Validate(); err != nil {
+		return CostMap{}, false, err
+	}
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return CostMap{}, false, err
+	}
+	defer c.release(counter)
+
+	costs := make([]uint32, c.client.Node_count())
+	cancelled, stop := cancellation(ctx)
+	snapped := c.client.One_to_all(counter, c.snapRadius, source.Lon, source.Lat, uintBuffer(costs), intBuffer(cancelled))
+	stop()
+	runtime.KeepAlive(costs)
+	runtime.KeepAlive(cancelled)
+	if atomic.LoadInt32(&cancelled[0]) != 0 {
+		return CostMap{}, false, ctx.Err()
+	}
+	return CostMap{Source: source, Costs: costs}, snapped, nil
+}
+
+// SampleCosts returns the costs from the source of m to the points, derived
+// from the costs of m without searching the road network again. Like a route,
+// the cost of a point includes the part of the arc between the point and the
+// node it is reached from. It is MaxDistance for points that cannot be snapped
+// or reached. SampleCosts returns an error if a point is invalid, if m was not
+// computed by OneToAll of a client on the same road network or if ctx is done
+// before a free query slot becomes available.
+func (c client) SampleCosts(ctx context.Context, m CostMap, points []Point) ([]uint32, error) {
+	if n := int(c.client.Node_count()); len(m.Costs) != n {
+		return nil, fmt.Errorf("cost map holds %d costs, but the road network has %d nodes", len(m.Costs), n)
+	}
+	if err := validatePoints(points...); err != nil {
+		return nil, err
+	}
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(counter)
+
+	sampled := make([]uint32, len(points))
+	if len(points) == 0 {
+		return sampled, nil
+	}
+	pointsVector := toSwigPoints(points)
+	defer routingkit.DeletePointVector(pointsVector)
+	c.client.Sample_costs(
+		c.snapRadius,
+		m.Source.Lon,
+		m.Source.Lat,
+		uintBuffer(m.Costs),
+		pointsVector,
+		uintBuffer(sampled),
+	)
+	runtime.KeepAlive(m.Costs)
+	runtime.KeepAlive(sampled)
+	return sampled, nil
+}
+
+// Nodes returns the position of every node of the road network. The travel
+// times of OneToAll are indexed by the same node indices.
+func (c TravelTimeClient) Nodes() []Point {
+	return c.client.Nodes()
+}
+
+// OneToAll computes the travel times from the source to all nodes of the road
+// network with a single sweep over the contraction hierarchy.
+func (c TravelTimeClient) OneToAll(ctx context.Context, source Point) (CostMap, bool, error) {
+	return c.client.OneToAll(ctx, source)
+}
+
+// SampleCosts returns the travel times from the source of m to the points,
+// derived from the travel times of m.
+func (c TravelTimeClient) SampleCosts(ctx context.Context, m CostMap, points []Point) ([]uint32, error) {
+	return c.client.SampleCosts(ctx, m, points)
+}
@@ -0,0 +1,313 @@
+package routingkit
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// ClientOption configures a client created with NewDistanceClient or
+// NewTravelTimeClient.
+type ClientOption func(*clientOptions) error
+
+// Logger receives progress messages while a client is being created. It is
+// satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+type clientOptions struct {
+	concurrency      int
+	snapRadius       float32
+	snapToArcs       bool
+	geometry         bool
+	maxStretch       float32
+	maxOverlap       float32
+	cellSize         float32
+	gpsSigma         float32
+	gpsBeta          float32
+	chPath           string
+	cacheDir         string
+	logger           Logger
+	crashDiagnostics io.Writer
+}
+
+func newClientOptions(opts []ClientOption) (clientOptions, error) {
+	options := clientOptions{
+		concurrency: runtime.GOMAXPROCS(0),
+		snapRadius:  1000,
+		maxStretch:  1.25,
+		maxOverlap:  0.5,
+		cellSize:    100,
+		gpsSigma:    10,
+		gpsBeta:     20,
+	}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return clientOptions{}, err
+		}
+	}
+	return options, nil
+}
+
+// WithConcurrency sets the number of queries that can run against the client at
+// the same time. Every concurrent query holds its own search state, so lowering
+// this bounds the memory used by the client. It defaults to
+// runtime.GOMAXPROCS(0).
+func WithConcurrency(n int) ClientOption {
+	return func(o *clientOptions) error {
+		if n < 1 {
+			return fmt.Errorf("concurrency must be at least 1, got %d", n)
+		}
+		o.concurrency = n
+		return nil
+	}
+}
+
+// WithSnapRadius sets the radius in meters within which query points are
+// snapped to the nearest street network point. It defaults to 1000 and can be
+// changed later with SetSnapRadius.
+func WithSnapRadius(radius float32) ClientOption {
+	return func(o *clientOptions) error {
+		if radius < 0 {
+			return fmt.Errorf("snap radius must not be negative, got %v", radius)
+		}
+		o.snapRadius = radius
+		return nil
+	}
+}
+
+// WithArcSnapping projects query points onto the nearest road segment within
+// the snap radius instead of snapping them to the nearest road network node.
+// Routes then start and end at the projected points, and their costs include
+// the parts of the segments between the projected points and the nodes. This
+// avoids large detours on long roads whose nodes are far apart. The points are
+// projected onto the shape of the roads, which is loaded as with WithGeometry.
+func WithArcSnapping() ClientOption {
+	return func(o *clientOptions) error {
+		o.snapToArcs = true
+		return nil
+	}
+}
+
+// WithGeometry loads the shape of the roads between intersections, so that
+// the waypoints of routes follow the roads instead of connecting the
+// intersections by straight lines. The shape is stored compactly, as the
+// differences between consecutive points, which increases the memory used by
+// the client by eight bytes per road segment and about four bytes per point.
+func WithGeometry() ClientOption {
+	return func(o *clientOptions) error {
+		o.geometry = true
+		return nil
+	}
+}
+
+// WithAlternativeLimits sets the limits for the alternative routes returned
+// by Alternatives: an alternative costs at most maxStretch times as much as the
+// shortest route, and at most the fraction maxOverlap of its length is shared
+// with any route returned before it. They default to 1.25 and 0.5.
+func WithAlternativeLimits(maxStretch, maxOverlap float32) ClientOption {
+	return func(o *clientOptions) error {
+		if maxStretch < 1 {
+			return fmt.Errorf("maximum stretch must be at least 1, got %v", maxStretch)
+		}
+		if maxOverlap < 0 || maxOverlap > 1 {
+			return fmt.Errorf("maximum overlap must be between 0 and 1, got %v", maxOverlap)
+		}
+		o.maxStretch = maxStretch
+		o.maxOverlap = maxOverlap
+		return nil
+	}
+}
+
+// WithIsochroneCellSize sets the side length in meters of the grid cells the
+// areas returned by Isochrone are made of. Smaller cells trace the reachable
+// roads more closely, but leave gaps between roads far from each other. It
+// defaults to 100.
+func WithIsochroneCellSize(size float32) ClientOption {
+	return func(o *clientOptions) error {
+		if size <= 0 {
+			return fmt.Errorf("isochrone cell size must be positive, got %v", size)
+		}
+		o.cellSize = size
+		return nil
+	}
+}
+
+// WithMapMatching sets the parameters of the model MapMatch matches GPS traces
+// with: sigma is the standard deviation in meters of the GPS positions, and
+// beta the typical difference in meters between the length of the route
+// between two consecutive positions and the straight distance between them.
+// Larger values tolerate noisier positions and more winding routes between
+// positions. They default to 10 and 20.
+func WithMapMatching(sigma, beta float32) ClientOption {
+	return func(o *clientOptions) error {
+		if sigma <= 0 {
+			return fmt.Errorf("GPS standard deviation must be positive, got %v", sigma)
+		}
+		if beta <= 0 {
+			return fmt.Errorf("route length deviation must be positive, got %v", beta)
+		}
+		o.gpsSigma = sigma
+		o.gpsBeta = beta
+		return nil
+	}
+}
+
+// WithCHPath sets the path of the contraction hierarchy file. The file is
+// created if it does not exist yet. A contraction hierarchy is specific to the
+// map, profile and measure it was built for, so the same path must not be
+// used for different clients. It takes precedence over WithCacheDir.
+func WithCHPath(path string) ClientOption {
+	return func(o *clientOptions) error {
+		if path == "" {
+			return fmt.Errorf("contraction hierarchy path must not be empty")
+		}
+		o.chPath = path
+		return nil
+	}
+}
+
+// WithCacheDir sets the directory in which the contraction hierarchy file is
+// stored, instead of next to the map file. The file name is still derived from
+// the map file, the profile and the measure of the client.
+func WithCacheDir(dir string) ClientOption {
+	return func(o *clientOptions) error {
+		if dir == "" {
+			return fmt.Errorf("cache directory must not be empty")
+		}
+		o.cacheDir = dir
+		return nil
+	}
+}
+
+// WithLogger sets a logger that is informed about the progress of creating the
+// client, e.g. whether the contraction hierarchy is loaded or built.
+func WithLogger(logger Logger) ClientOption {
+	return func(o *clientOptions) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// WithCrashDiagnostics installs handlers for fatal signals (SIGSEGV, SIGBUS,
+// SIGILL, SIGABRT, SIGFPE and SIGSYS) that write a stack trace of the C++
+// routing code to w before the process terminates. If w is nil, the stack
+// trace is written to os.Stderr.
+//
+// If w is an *os.File, the handlers write to its file descriptor directly.
+// Any other writer receives the stack trace from a goroutine reading it from a
+// pipe, since a signal handler cannot call Go code. The handlers wait up to a
+// second for the goroutine to forward the stack trace before the process
+// terminates. Goroutines keep running during a crash of the routing code.
+//
+// The handlers are installed for the whole process once the map has been
+// loaded. Faults in Go code are passed on to the handlers of the Go runtime, so
+// that e.g. a nil pointer dereference in Go code still panics. By default, no
+// handlers are installed.
+func WithCrashDiagnostics(w io.Writer) ClientOption {
+	return func(o *clientOptions) error {
+		if f, ok := w.(*os.File); w == nil || ok && f == nil {
+			w = os.Stderr
+		}
+		o.crashDiagnostics = w
+		return nil
+	}
+}
+
+// chFile returns the path of the contraction hierarchy file given the default
+// path derived from the map file.
+func (o clientOptions) chFile(defaultPath string) string {
+	if o.chPath != "" {
+		return o.chPath
+	}
+	if o.cacheDir != "" {
+		return filepath.Join(o.cacheDir, filepath.Base(defaultPath))
+	}
+	return defaultPath
+}
+
+func (o clientOptions) logf(format string, v ...interface{}) {
+	if o.logger != nil {
+		o.logger.Printf(format, v...)
+	}
+}
+
+var crashDiagnostics struct {
+	sync.Mutex
+	// file is kept so that its file descriptor is not closed by the garbage
+	// collector while the signal handlers may still write to it.
+	file *os.File
+	// pipe holds the ends of the pipes used by the signal handlers if the
+	// crash diagnostics are forwarded to a writer other than a file.
+	pipe []*os.File
+}
+
+// install performs the process-wide setup requested by the options.
+func (o clientOptions) install() error {
+	if o.crashDiagnostics == nil {
+		return nil
+	}
+	crashDiagnostics.Lock()
+	defer crashDiagnostics.Unlock()
+
+	var file *os.File
+	var pipe []*os.File
+	forwarded := -1
+	if f, ok := o.crashDiagnostics.(*os.File); ok {
+		file = f
+	} else {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return fmt.Errorf("creating crash diagnostics pipe: %v", err)
+		}
+		done, hangUp, err := os.Pipe()
+		if err != nil {
+			r.Close()
+			w.Close()
+			return fmt.Errorf("creating crash diagnostics pipe: %v", err)
+		}
+		go forwardCrashDiagnostics(o.crashDiagnostics, r, hangUp)
+		file = w
+		pipe = []*os.File{w, done}
+		forwarded = int(done.Fd())
+	}
+	start, end := goText()
+	routingkit.Install_crash_handlers(int(file.Fd()), forwarded, uint64(start), uint64(end))
+
+	// the pipes of handlers installed before are not used anymore
+	for _, f := range crashDiagnostics.pipe {
+		f.Close()
+	}
+	crashDiagnostics.file = file
+	crashDiagnostics.pipe = pipe
+	return nil
+}
+
+// goText returns the range of addresses holding the machine code of the Go
+// functions of the process, whose faults the crash handlers leave to the Go
+// runtime. The Go functions take up a single range, whose bounds are searched
+// for as the first addresses around goText that runtime.FuncForPC does not
+// know.
+func goText() (start, end uintptr) {
+	pc := reflect.ValueOf(goText).Pointer()
+	isGo := func(pc uintptr) bool { return runtime.FuncForPC(pc) != nil }
+	below := sort.Search(int(pc)+1, func(i int) bool { return !isGo(pc - uintptr(i)) })
+	above := sort.Search(int(^uintptr(0)>>1-pc), func(i int) bool { return !isGo(pc + uintptr(i)) })
+	return pc - uintptr(below) + 1, pc + uintptr(above)
+}
+
+// forwardCrashDiagnostics copies the stack trace the signal handlers write to
+// r to w. Closing hangUp afterwards lets the process terminate.
+func forwardCrashDiagnostics(w io.Writer, r, hangUp *os.File) {
+	_, _ = io.Copy(w, r)
+	r.Close()
+	hangUp.Close()
+}
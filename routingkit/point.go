@@ -0,0 +1,237 @@
+package routingkit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// Point is a location given by its longitude and latitude in degrees.
+type Point struct {
+	Lon float32
+	Lat float32
+}
+
+// LatLng is a location given by its latitude and longitude in degrees, in the
+// order commonly used by geocoders.
+type LatLng struct {
+	Lat float32
+	Lng float32
+}
+
+// Point returns the location as a Point.
+func (l LatLng) Point() Point {
+	return Point{Lon: l.Lng, Lat: l.Lat}
+}
+
+// LatLng returns the location as a LatLng.
+func (p Point) LatLng() LatLng {
+	return LatLng{Lat: p.Lat, Lng: p.Lon}
+}
+
+// Validate returns an error if the longitude or latitude of the point is out
+// of range.
+func (p Point) Validate() error {
+	if math.IsNaN(float64(p.Lon)) || p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("invalid point %v: longitude must be within [-180, 180]", p)
+	}
+	if math.IsNaN(float64(p.Lat)) || p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("invalid point %v: latitude must be within [-90, 90]", p)
+	}
+	return nil
+}
+
+// HeadedPoint is a point at which a vehicle travels in a known direction,
+// e.g. as reported by a GPS device with a compass. Headed points are only
+// taken by FindHeadedRoute, ComputeHeadedDistances and
+// ComputeHeadedTravelTimes. All other queries, including matrices, Route,
+// Distances and the queries of a CombinedClient other than FindHeadedRoute,
+// snap points without headings.
+type HeadedPoint struct {
+	Point Point
+	// Bearing is the direction of travel in degrees clockwise from north.
+	Bearing float32
+	// Tolerance is the largest difference in degrees between Bearing and the
+	// direction of the piece of road the point is projected onto. The pieces
+	// follow the shape of the road if it is loaded with WithGeometry or
+	// WithArcSnapping, and run straight between intersections otherwise. If
+	// Tolerance is 0, the point has no heading and is snapped like a Point.
+	Tolerance float32
+}
+
+// Validate returns an error if the point is invalid or if the bearing or the
+// tolerance is out of range.
+func (p HeadedPoint) Validate() error {
+	if err := p.Point.Validate(); err != nil {
+		return err
+	}
+	if math.IsNaN(float64(p.Bearing)) || p.Bearing < 0 || p.Bearing > 360 {
+		return fmt.Errorf("invalid bearing %v: must be within [0, 360]", p.Bearing)
+	}
+	if math.IsNaN(float64(p.Tolerance)) || p.Tolerance < 0 || p.Tolerance > 180 {
+		return fmt.Errorf("invalid tolerance %v: must be within [0, 180]", p.Tolerance)
+	}
+	return nil
+}
+
+// headed returns the points without headings.
+func headed(points []Point) []HeadedPoint {
+	converted := make([]HeadedPoint, len(points))
+	for i, p := range points {
+		converted[i] = HeadedPoint{Point: p}
+	}
+	return converted
+}
+
+// Status describes the outcome of a query between two points.
+type Status routingkit.Query_status
+
+var (
+	// StatusOK means that a route was found.
+	StatusOK Status = Status(routingkit.Status_ok)
+	// StatusSourceNotSnapped means that there is no road network point within
+	// the snap radius of the source.
+	StatusSourceNotSnapped Status = Status(routingkit.Status_source_not_snapped)
+	// StatusTargetNotSnapped means that there is no road network point within
+	// the snap radius of the target.
+	StatusTargetNotSnapped Status = Status(routingkit.Status_target_not_snapped)
+	// StatusNoPath means that both points were snapped, but the road network
+	// does not connect them.
+	StatusNoPath Status = Status(routingkit.Status_no_path)
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusSourceNotSnapped:
+		return "source not snapped"
+	case StatusTargetNotSnapped:
+		return "target not snapped"
+	case StatusNoPath:
+		return "no path"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// RouteResult describes the route found between two points.
+type RouteResult struct {
+	// Cost is the measure minimized by the client, i.e. the distance in
+	// meters or the travel time in milliseconds. It is MaxDistance if the
+	// route is not reachable.
+	Cost uint32
+	// Distance and TravelTime are the length in meters and the travel time in
+	// milliseconds of the route.
+	Distance   uint32
+	TravelTime uint32
+	// Waypoints describe the route from the snapped source to the snapped
+	// target.
+	Waypoints []Point
+	// Segments describe the route road segment by road segment. They are only
+	// set by FindRoute.
+	Segments []Segment
+	// Source and Target are the road network points the endpoints were
+	// snapped to. They are zero if an endpoint could not be snapped.
+	Source Point
+	Target Point
+	// Reachable is false if no route was found, in which case Status tells
+	// why.
+	Reachable bool
+	Status    Status
+}
+
+// Segment describes the part of a route along a single road segment, i.e.
+// between two intersections.
+type Segment struct {
+	// WayID is the ID of the OSM way of the segment, and Name, Ref and Highway
+	// are the values of its name, ref and highway tags.
+	WayID   int64
+	Name    string
+	Ref     string
+	Highway string
+	// Distance and Duration are the length in meters and the travel time in
+	// milliseconds of the part of the segment that is traveled, and Speed is
+	// the speed in km/h used to compute the travel time.
+	Distance uint32
+	Duration uint32
+	Speed    uint32
+	// From and To are the start and end of the part of the segment that is
+	// traveled.
+	From Point
+	To   Point
+	// StartBearing and EndBearing are the directions of travel in degrees
+	// clockwise from north at From and To. They follow the shape of the road
+	// next to the points if it was loaded with WithGeometry, and the straight
+	// line from From to To otherwise.
+	StartBearing float64
+	EndBearing   float64
+	// Roundabout is true if the segment is part of a roundabout.
+	Roundabout bool
+	// Branches is the number of roads leaving the end of the segment, other
+	// than the one leading back. It is zero if the route ends before the end
+	// of the segment.
+	Branches int
+}
+
+// SnapResult describes where a point was snapped to the road network.
+type SnapResult struct {
+	// Point is the road network point the query point was snapped to.
+	Point Point
+	// Distance is the straight-line distance in meters from the query point
+	// to Point.
+	Distance float32
+	// WayID is the ID of the OSM way at Point closest to the query point, and
+	// Name and Highway are the values of its name and highway tags.
+	WayID   int64
+	Name    string
+	Highway string
+}
+
+// pointFromSlice converts a point given as []float32{lon, lat}.
+func pointFromSlice(p []float32) (Point, error) {
+	if len(p) != 2 {
+		return Point{}, fmt.Errorf("invalid point %v: expected longitude and latitude", p)
+	}
+	point := Point{Lon: p[0], Lat: p[1]}
+	if err := point.Validate(); err != nil {
+		return Point{}, err
+	}
+	return point, nil
+}
+
+func pointsFromSlices(points [][]float32) ([]Point, error) {
+	converted := make([]Point, len(points))
+	for i, p := range points {
+		point, err := pointFromSlice(p)
+		if err != nil {
+			return nil, err
+		}
+		converted[i] = point
+	}
+	return converted, nil
+}
+
+func validatePoints(points ...Point) error {
+	for _, p := range points {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toSlice(p Point) []float32 {
+	return []float32{p.Lon, p.Lat}
+}
+
+func toSlices(points []Point) [][]float32 {
+	if points == nil {
+		return nil
+	}
+	converted := make([][]float32, len(points))
+	for i, p := range points {
+		converted[i] = toSlice(p)
+	}
+	return converted
+}
@@ -11,6 +11,8 @@ import (
 	"runtime"
 	"sort"
 	"strconv"
+	"sync/atomic"
+	"unsafe"
 
 	"github.com/golang/geo/s2"
 	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
@@ -25,10 +27,14 @@ var Keep bool = keep.Keep
 // MaxDistance represents the maximum possible route distance.
 var MaxDistance uint32
 
-func parsePBF(osmFile string, tagMapFilter TagMapFilter, speedMapper SpeedMapper) (map[int]bool, map[int]int) {
+func parsePBF(
+	osmFile string,
+	tagMapFilter TagMapFilter,
+	speedMapper SpeedMapper,
+) (map[int]bool, map[int]int, error) {
 	file, err := os.Open(osmFile)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 	defer file.Close()
 
@@ -64,10 +70,10 @@ func parsePBF(osmFile string, tagMapFilter TagMapFilter, speedMapper SpeedMapper
 	}
 
 	if err := scanner.Err(); err != nil {
-		panic(err)
+		return nil, nil, fmt.Errorf("reading map file %v: %v", osmFile, err)
 	}
 
-	return allowed, waySpeeds
+	return allowed, waySpeeds, nil
 }
 
 func Car() Profile {
@@ -165,42 +171,158 @@ func withSwigProfile(p Profile, allowedWayIDs map[int]bool, waySpeeds map[int]in
 	f(customProfile)
 }
 
+// invalidArc marks a route that does not contain any arc.
+var invalidArc uint
+
 func init() {
 	MaxDistance = uint32(routingkit.GetMax_distance())
+	invalidArc = routingkit.GetInvalid_arc()
 }
 
 // NewDistanceClient initializes a DistanceClient using the provided .osm.pbf file and
 // .ch file. The .ch file will be created if it does not already exist. It is the caller's
 // responsibility to call Delete on the client when it is no longer needed.
-func NewDistanceClient(mapFile string, profile Profile) (DistanceClient, error) {
+func NewDistanceClient(mapFile string, profile Profile, opts ...ClientOption) (DistanceClient, error) {
+	c, err := newClient(mapFile, profile, false, opts)
+	if err != nil {
+		return DistanceClient{}, err
+	}
+	return DistanceClient{client: c}, nil
+}
+
+// newClient loads the map file into a new client whose contraction hierarchy
+// minimizes either the travel time or the distance.
+func newClient(mapFile string, profile Profile, travelTime bool, opts []ClientOption) (client, error) {
+	options, err := newClientOptions(opts)
+	if err != nil {
+		return client{}, err
+	}
+	m, err := parseMap(mapFile, profile, options)
+	if err != nil {
+		return client{}, err
+	}
+	chFile, err := m.chFile(travelTime, options)
+	if err != nil {
+		return client{}, err
+	}
+
+	network, err := m.load(options)
+	if err != nil {
+		return client{}, err
+	}
+	if err := options.install(); err != nil {
+		routingkit.DeleteRoadNetwork(network)
+		return client{}, err
+	}
+	c, err := newNetworkClient(network, chFile, travelTime, options)
+	if err != nil {
+		routingkit.DeleteRoadNetwork(network)
+		return client{}, err
+	}
+	c.network = network
+	return c, nil
+}
+
+// parsedMap holds the ways of a map file that are usable with a profile.
+type parsedMap struct {
+	mapFile       string
+	profile       Profile
+	allowedWayIDs map[int]bool
+	waySpeeds     map[int]int
+}
+
+func parseMap(mapFile string, profile Profile, options clientOptions) (parsedMap, error) {
 	if _, err := os.Stat(mapFile); os.IsNotExist(err) {
-		return DistanceClient{}, fmt.Errorf("could not find map file at %v", mapFile)
+		return parsedMap{}, fmt.Errorf("could not find map file at %v", mapFile)
 	}
 
-	allowedWayIDs, waySpeeds := parsePBF(mapFile, profile.Filter, profile.SpeedMapper)
+	options.logf("parsing map file %v", mapFile)
+	allowedWayIDs, waySpeeds, err := parsePBF(mapFile, profile.Filter, profile.SpeedMapper)
+	if err != nil {
+		return parsedMap{}, err
+	}
+	return parsedMap{
+		mapFile:       mapFile,
+		profile:       profile,
+		allowedWayIDs: allowedWayIDs,
+		waySpeeds:     waySpeeds,
+	}, nil
+}
 
-	chFile, err := chFileName(mapFile, profile, allowedWayIDs, waySpeeds, false)
+// chFile returns the path of the contraction hierarchy file for the given
+// measure.
+func (m parsedMap) chFile(travelTime bool, options clientOptions) (string, error) {
+	chFile, err := chFileName(m.mapFile, m.profile, m.allowedWayIDs, m.waySpeeds, travelTime)
 	if err != nil {
-		return DistanceClient{}, err
+		return "", err
 	}
+	return options.chFile(chFile), nil
+}
 
-	concurrentQueries := runtime.GOMAXPROCS(0)
-	var c routingkit.Client
-	withSwigProfile(profile, allowedWayIDs, waySpeeds, func(customProfile routingkit.Profile) {
-		c = routingkit.NewClient(concurrentQueries, mapFile, chFile, customProfile)
+// load builds the road network of the map in C++. It is the caller's
+// responsibility to delete the network when it is no longer needed.
+func (m parsedMap) load(options clientOptions) (routingkit.RoadNetwork, error) {
+	options.logf("loading road network from %v", m.mapFile)
+	var network routingkit.RoadNetwork
+	withSwigProfile(m.profile, m.allowedWayIDs, m.waySpeeds, func(swigProfile routingkit.Profile) {
+		// clients that snap to arcs project points onto the shape of the roads
+		network = routingkit.NewRoadNetwork(m.mapFile, swigProfile, options.geometry || options.snapToArcs)
 	})
+	if msg := network.Load_error(); msg != "" {
+		routingkit.DeleteRoadNetwork(network)
+		return nil, fmt.Errorf("loading routing data: %v", msg)
+	}
+	return network, nil
+}
+
+// newNetworkClient creates a client for the given road network, which must
+// not be deleted before the client.
+func newNetworkClient(
+	network routingkit.RoadNetwork,
+	chFile string,
+	travelTime bool,
+	options clientOptions,
+) (client, error) {
+	if _, err := os.Stat(chFile); err == nil {
+		options.logf("loading contraction hierarchy from %v", chFile)
+	} else {
+		options.logf("building contraction hierarchy at %v", chFile)
+	}
+
+	concurrentQueries := options.concurrency
+	c := routingkit.NewClient(concurrentQueries, network, chFile, travelTime, options.snapToArcs)
+	if err := loadError(c); err != nil {
+		return client{}, err
+	}
+	options.logf("client ready for %d concurrent queries", concurrentQueries)
 
 	channel := make(chan int, concurrentQueries)
 	for i := 0; i < concurrentQueries; i++ {
 		channel <- i
 	}
 
-	return DistanceClient{
-		client: client{
-			client:     c,
-			channel:    channel,
-			snapRadius: 1000,
-		}}, nil
+	return client{
+		client:     c,
+		channel:    channel,
+		snapRadius: options.snapRadius,
+		maxStretch: options.maxStretch,
+		maxOverlap: options.maxOverlap,
+		cellSize:   options.cellSize,
+		gpsSigma:   options.gpsSigma,
+		gpsBeta:    options.gpsBeta,
+	}, nil
+}
+
+// loadError returns the error that occurred while loading the contraction
+// hierarchy into the given client, if any. In that case the client is deleted
+// and must not be used anymore.
+func loadError(c routingkit.Client) error {
+	msg := c.Load_error()
+	if msg == "" {
+		return nil
+	}
+	routingkit.DeleteClient(c)
+	return fmt.Errorf("loading routing data: %v", msg)
 }
 
 func chFileName(mapFile string, profile Profile, allowedWayIDs map[int]bool, waySpeeds map[int]int, duration bool) (string, error) {
@@ -253,7 +375,13 @@ func chFileName(mapFile string, profile Profile, allowedWayIDs map[int]bool, way
 
 // Delete deletes the client, releasing memory allocated for C++ routing data structures
 func (c *client) Delete() {
+	if c.shared {
+		return
+	}
 	routingkit.DeleteClient(c.client)
+	if c.network != nil {
+		routingkit.DeleteRoadNetwork(c.network)
+	}
 }
 
 // SetSnapRadius updates Client so that all queries will snap points to the nearest
@@ -268,138 +396,749 @@ type DistanceClient struct {
 
 // client allows routing queries to be executed against a particular region.
 type client struct {
-	client     routingkit.Client
+	client routingkit.Client
+	// network is the road network owned by the client. It is nil if the
+	// network is owned by a CombinedClient.
+	network routingkit.RoadNetwork
+	// shared is set if the client is owned by a CombinedClient, in which case
+	// it is deleted together with that client.
+	shared     bool
 	channel    chan int
 	snapRadius float32
+	// maxStretch and maxOverlap limit the alternative routes.
+	maxStretch float32
+	maxOverlap float32
+	// cellSize is the side length in meters of the cells of isochrones.
+	cellSize float32
+	// gpsSigma and gpsBeta are the parameters of the map matching model.
+	gpsSigma float32
+	gpsBeta  float32
+}
+
+// acquire waits for a free query slot on the client. It returns ctx.Err() if
+// the context is done before a slot becomes available.
+func (c client) acquire(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	select {
+	case counter := <-c.channel:
+		return counter, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// release returns a query slot obtained with acquire to the client.
+func (c client) release(counter int) {
+	c.channel <- counter
+}
+
+// acquireAll waits for a free query slot like acquire, and then takes up to
+// n - 1 more slots that are free at the moment, so that a search can run on
+// one thread per slot without waiting for other queries to finish. The slots
+// must be released with releaseAll.
+func (c client) acquireAll(ctx context.Context, n int) ([]int, error) {
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slots := []int{counter}
+	for len(slots) < n {
+		select {
+		case counter := <-c.channel:
+			slots = append(slots, counter)
+		default:
+			return slots, nil
+		}
+	}
+	return slots, nil
+}
+
+// releaseAll returns query slots obtained with acquireAll to the client.
+func (c client) releaseAll(slots []int) {
+	for _, counter := range slots {
+		c.release(counter)
+	}
+}
+
+// cancellation returns a flag that is set to 1 once ctx is done, to be passed
+// to the C++ searches, which check it between rows or, within a single search,
+// every so many nodes. The returned function must be called once the searches
+// returned.
+func cancellation(ctx context.Context) ([]int32, func()) {
+	cancelled := make([]int32, 1)
+	if ctx.Done() == nil {
+		return cancelled, func() {}
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			atomic.StoreInt32(&cancelled[0], 1)
+		case <-stop:
+		}
+	}()
+	return cancelled, func() {
+		close(stop)
+		<-stopped
+	}
 }
 
 // Route finds the fastest route between the two points, returning the total route
 // distance and the waypoints describing the route.
 func (c client) Route(from []float32, to []float32) (uint32, [][]float32) {
-	counter := <-c.channel
-	defer func() {
-		c.channel <- counter
-	}()
-	resp := c.client.Query(
-		int(counter),
-		float32(c.snapRadius),
-		float32(from[0]),
-		float32(from[1]),
-		float32(to[0]),
-		float32(to[1]),
-		true,
-	)
-	defer routingkit.DeleteQueryResponse(resp)
-	wp := resp.GetWaypoints()
-	waypoints := make([][]float32, wp.Size())
-	for i := 0; i < len(waypoints); i++ {
-		p := wp.Get(i)
-		waypoints[i] = []float32{float32(p.GetLon()), float32(p.GetLat())}
+	distance, waypoints, _ := c.RouteContext(context.Background(), from, to)
+	return distance, waypoints
+}
+
+// RouteContext is like Route, but gives up waiting for a free query slot when
+// ctx is done, in which case ctx.Err() is returned. It also returns an error if
+// a point is invalid.
+func (c client) RouteContext(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+) (uint32, [][]float32, error) {
+	result, err := c.findRoute(ctx, from, to, true)
+	if err != nil {
+		return MaxDistance, nil, err
 	}
+	return result.Cost, toSlices(result.Waypoints), nil
+}
 
-	return uint32(resp.GetDistance()), waypoints
+// FindRoute finds the route between the two points minimizing the measure of
+// the client. It returns an error if a point is invalid or if ctx is done
+// before a free query slot becomes available.
+func (c client) FindRoute(ctx context.Context, from Point, to Point) (RouteResult, error) {
+	if err := validatePoints(from, to); err != nil {
+		return RouteResult{}, err
+	}
+	return c.route(ctx, HeadedPoint{Point: from}, HeadedPoint{Point: to}, true, true)
 }
 
-// Distance returns the length of the shortest possible route between the points
-func (c client) Distance(from []float32, to []float32) uint32 {
-	counter := <-c.channel
-	defer func() {
-		c.channel <- counter
-	}()
+// FindHeadedRoute is like FindRoute, but snaps a point with a heading to the
+// nearest road within the snap radius that runs in its direction of travel, so
+// that e.g. a vehicle on a divided highway is not snapped to the opposite
+// carriageway. If there is no such road, the point is snapped like a Point.
+// It returns an error if a point is invalid or if ctx is done before a free
+// query slot becomes available.
+func (c client) FindHeadedRoute(ctx context.Context, from HeadedPoint, to HeadedPoint) (RouteResult, error) {
+	if err := from.Validate(); err != nil {
+		return RouteResult{}, err
+	}
+	if err := to.Validate(); err != nil {
+		return RouteResult{}, err
+	}
+	return c.route(ctx, from, to, true, true)
+}
+
+// findRoute is like FindRoute, but takes points as []float32{lon, lat}.
+func (c client) findRoute(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+	includeWaypoints bool,
+) (RouteResult, error) {
+	f, err := pointFromSlice(from)
+	if err != nil {
+		return RouteResult{}, err
+	}
+	t, err := pointFromSlice(to)
+	if err != nil {
+		return RouteResult{}, err
+	}
+	return c.route(ctx, HeadedPoint{Point: f}, HeadedPoint{Point: t}, includeWaypoints, false)
+}
+
+func (c client) route(
+	ctx context.Context,
+	from HeadedPoint,
+	to HeadedPoint,
+	includeWaypoints bool,
+	includeSegments bool,
+) (RouteResult, error) {
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return RouteResult{}, err
+	}
+	defer c.release(counter)
 	resp := c.client.Query(
-		int(counter),
+		counter,
 		c.snapRadius,
-		from[0],
-		from[1],
-		to[0],
-		to[1],
-		false,
+		from.Point.Lon,
+		from.Point.Lat,
+		from.Bearing,
+		from.Tolerance,
+		to.Point.Lon,
+		to.Point.Lat,
+		to.Bearing,
+		to.Tolerance,
+		includeWaypoints,
+		includeSegments,
 	)
 	defer routingkit.DeleteQueryResponse(resp)
+	return routeResult(resp), nil
+}
 
-	return uint32(resp.GetDistance())
+func routeResult(resp routingkit.QueryResponse) RouteResult {
+	cost := uint32(resp.GetDistance())
+	return RouteResult{
+		Cost:       cost,
+		Distance:   uint32(resp.GetGeo_distance()),
+		TravelTime: uint32(resp.GetTravel_time()),
+		Waypoints:  toPoints(resp.GetWaypoints()),
+		Segments:   toSegments(resp.GetSegments()),
+		Source:     toPoint(resp.GetSource()),
+		Target:     toPoint(resp.GetTarget()),
+		Reachable:  cost != MaxDistance,
+		Status:     Status(resp.GetStatus()),
+	}
 }
 
-type distanceMatrixRow struct {
-	i         int
-	distances []uint32
+func toPoint(p routingkit.Point) Point {
+	return Point{Lon: p.GetLon(), Lat: p.GetLat()}
+}
+
+func toPoints(wp routingkit.PointVector) []Point {
+	points := make([]Point, wp.Size())
+	for i := 0; i < len(points); i++ {
+		points[i] = toPoint(wp.Get(i))
+	}
+	return points
+}
+
+func toSegments(sv routingkit.SegmentVector) []Segment {
+	if sv.Size() == 0 {
+		return nil
+	}
+	segments := make([]Segment, sv.Size())
+	for i := range segments {
+		s := sv.Get(i)
+		segments[i] = Segment{
+			WayID:        s.GetWay_id(),
+			Name:         s.GetName(),
+			Ref:          s.GetRef(),
+			Highway:      s.GetHighway(),
+			Distance:     uint32(s.GetDistance()),
+			Duration:     uint32(s.GetDuration()),
+			Speed:        uint32(s.GetSpeed()),
+			From:         toPoint(s.GetFrom()),
+			To:           toPoint(s.GetTo()),
+			StartBearing: float64(s.GetStart_bearing()),
+			EndBearing:   float64(s.GetEnd_bearing()),
+			Roundabout:   s.GetRoundabout(),
+			Branches:     int(s.GetBranches()),
+		}
+	}
+	return segments
+}
+
+func toSwigInts(values []int) routingkit.IntVector {
+	vector := routingkit.NewIntVector()
+	for _, v := range values {
+		vector.Add(v)
+	}
+	return vector
+}
+
+func toSwigPoints(points []Point) routingkit.PointVector {
+	vector := routingkit.NewPointVector(int64(len(points)))
+	for i, p := range points {
+		point := routingkit.NewPoint()
+		point.SetLon(p.Lon)
+		point.SetLat(p.Lat)
+		vector.Set(i, point)
+		routingkit.DeletePoint(point)
+	}
+	return vector
+}
+
+// Distance returns the length of the shortest possible route between the points
+func (c client) Distance(from []float32, to []float32) uint32 {
+	distance, _ := c.DistanceContext(context.Background(), from, to)
+	return distance
+}
+
+// DistanceContext is like Distance, but gives up waiting for a free query slot
+// when ctx is done, in which case ctx.Err() is returned. It also returns an
+// error if a point is invalid.
+func (c client) DistanceContext(ctx context.Context, from []float32, to []float32) (uint32, error) {
+	result, err := c.findRoute(ctx, from, to, false)
+	if err != nil {
+		return MaxDistance, err
+	}
+	return result.Cost, nil
 }
 
 // Nearest returns the nearest point in the road network within the radius configured on
 // the Client. The second argument will be false if no point could be found.
 func (c client) Nearest(point []float32) ([]float32, bool) {
-	counter := <-c.channel
-	defer func() {
-		c.channel <- counter
-	}()
-	res := c.client.Nearest(counter, c.snapRadius, point[0], point[1])
+	nearest, ok, _ := c.NearestContext(context.Background(), point)
+	return nearest, ok
+}
+
+// NearestContext is like Nearest, but gives up waiting for a free query slot
+// when ctx is done, in which case ctx.Err() is returned. It also returns an
+// error if the point is invalid.
+func (c client) NearestContext(ctx context.Context, point []float32) ([]float32, bool, error) {
+	p, err := pointFromSlice(point)
+	if err != nil {
+		return nil, false, err
+	}
+	nearest, ok, err := c.FindNearest(ctx, p)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return toSlice(nearest), true, nil
+}
+
+// FindNearest returns the nearest point in the road network within the radius
+// configured on the client. The second return value is false if no point could
+// be found. It returns an error if the point is invalid or if ctx is done
+// before a free query slot becomes available.
+func (c client) FindNearest(ctx context.Context, point Point) (Point, bool, error) {
+	if err := point.Validate(); err != nil {
+		return Point{}, false, err
+	}
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return Point{}, false, err
+	}
+	defer c.release(counter)
+	res := c.client.Nearest(counter, c.snapRadius, point.Lon, point.Lat)
 	if res.Swigcptr() == 0 {
-		return nil, false
+		return Point{}, false, nil
 	}
 	defer routingkit.DeletePoint(res)
-	return []float32{res.GetLon(), res.GetLat()}, true
+	return toPoint(res), true, nil
+}
+
+// Snap snaps the point to the nearest point in the road network within the
+// radius configured on the client and describes the way it was snapped to. The
+// second return value is false if no point could be found. It returns an error
+// if the point is invalid or if ctx is done before a free query slot becomes
+// available.
+func (c client) Snap(ctx context.Context, point Point) (SnapResult, bool, error) {
+	if err := point.Validate(); err != nil {
+		return SnapResult{}, false, err
+	}
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return SnapResult{}, false, err
+	}
+	defer c.release(counter)
+	res := c.client.Snap(counter, c.snapRadius, point.Lon, point.Lat)
+	defer routingkit.DeleteSnapResponse(res)
+	if !res.GetSnapped() {
+		return SnapResult{}, false, nil
+	}
+	return SnapResult{
+		Point:    toPoint(res.GetPoint()),
+		Distance: res.GetDistance(),
+		WayID:    res.GetWay_id(),
+		Name:     res.GetName(),
+		Highway:  res.GetHighway(),
+	}, true, nil
 }
 
 // Matrix creates a matrix representing the minimum distances from the points in
 // sources to the points in targets.
 func (c client) Matrix(sources [][]float32, targets [][]float32) [][]uint32 {
-	matrix := make([][]uint32, len(sources))
+	matrix, _ := c.MatrixContext(context.Background(), sources, targets)
+	return matrix
+}
 
-	workers := make(chan struct{}, runtime.GOMAXPROCS(0))
-	results := make(chan distanceMatrixRow)
+// MatrixContext is like Matrix, but stops computing new rows once ctx is done.
+// In that case the returned matrix is partial: rows that were not computed are
+// nil, and the returned error is ctx.Err(). A nil error means the matrix is
+// complete. If a point is invalid, no matrix is computed and an error is
+// returned.
+func (c client) MatrixContext(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+) ([][]uint32, error) {
+	s, err := pointsFromSlices(sources)
+	if err != nil {
+		return nil, err
+	}
+	t, err := pointsFromSlices(targets)
+	if err != nil {
+		return nil, err
+	}
+	return c.matrix(ctx, s, t)
+}
 
-	go func() {
-		for i, source := range sources {
-			workers <- struct{}{}
-			go func(i int, source []float32) {
-				distances := c.Distances(source, targets)
-				results <- distanceMatrixRow{i, distances}
-				<-workers
-			}(i, source)
-		}
-	}()
+// ComputeMatrix is like MatrixContext, but takes the points as Points. There
+// is no matrix of HeadedPoints: if the headings matter, ComputeHeadedDistances
+// computes the row of each source.
+func (c client) ComputeMatrix(ctx context.Context, sources []Point, targets []Point) ([][]uint32, error) {
+	if err := validatePoints(sources...); err != nil {
+		return nil, err
+	}
+	if err := validatePoints(targets...); err != nil {
+		return nil, err
+	}
+	return c.matrix(ctx, sources, targets)
+}
+
+// MatrixInto is like Matrix, but writes the distances row by row into dst
+// instead of allocating a matrix: the distance from sources[i] to targets[j] is
+// stored in dst[i*len(targets)+j]. It returns an error if a point is invalid or
+// if dst holds less than len(sources)*len(targets) elements.
+func (c client) MatrixInto(dst []uint32, sources [][]float32, targets [][]float32) error {
+	return c.MatrixIntoContext(context.Background(), dst, sources, targets)
+}
 
-	for range sources {
-		matrixRow := <-results
-		matrix[matrixRow.i] = matrixRow.distances
+// MatrixIntoContext is like MatrixInto, but stops computing new rows once ctx
+// is done, in which case the rows that were not computed are left unchanged in
+// dst and ctx.Err() is returned.
+func (c client) MatrixIntoContext(ctx context.Context, dst []uint32, sources [][]float32, targets [][]float32) error {
+	s, err := pointsFromSlices(sources)
+	if err != nil {
+		return err
+	}
+	t, err := pointsFromSlices(targets)
+	if err != nil {
+		return err
+	}
+	return c.matrixInto(ctx, dst, s, t)
+}
+
+// ComputeMatrixInto is like MatrixIntoContext, but takes the points as Points.
+func (c client) ComputeMatrixInto(ctx context.Context, dst []uint32, sources []Point, targets []Point) error {
+	if err := validatePoints(sources...); err != nil {
+		return err
+	}
+	if err := validatePoints(targets...); err != nil {
+		return err
+	}
+	return c.matrixInto(ctx, dst, sources, targets)
+}
+
+func (c client) matrixInto(ctx context.Context, dst []uint32, sources []Point, targets []Point) error {
+	cells := len(sources) * len(targets)
+	if len(dst) < cells {
+		return fmt.Errorf("matrix of %d cells does not fit into buffer of %d elements", cells, len(dst))
+	}
+	_, err := c.manyToMany(ctx, sources, targets, dst[:cells], nil, nil, nil)
+	return err
+}
+
+func (c client) matrix(ctx context.Context, sources []Point, targets []Point) ([][]uint32, error) {
+	distances := make([]uint32, len(sources)*len(targets))
+	computed, err := c.manyToMany(ctx, sources, targets, distances, nil, nil, nil)
+	return computedRows(distances, len(sources), len(targets), computed), err
+}
+
+// manyToMany computes the distances from the sources to the targets with a
+// single many-to-many search, storing the cells row by row in distances. If
+// statuses, geoDistances or travelTimes are not nil, the status, length and
+// travel time of the routes are stored in them as well. Each buffer must hold
+// one element per cell. The search runs on one thread per query slot it takes
+// up, which are as many as are free, up to one per source.
+//
+// It returns the number of rows computed, which are the first ones. Once ctx is
+// done, no more rows are computed, and ctx.Err() is returned if the matrix is
+// not complete.
+func (c client) manyToMany(
+	ctx context.Context,
+	sources []Point,
+	targets []Point,
+	distances []uint32,
+	statuses []int32,
+	geoDistances []uint32,
+	travelTimes []uint32,
+) (int, error) {
+	slots, err := c.acquireAll(ctx, len(sources))
+	if err != nil {
+		return 0, err
+	}
+	defer c.releaseAll(slots)
+	if len(sources) == 0 || len(targets) == 0 {
+		return len(sources), nil
 	}
 
+	slotsVector := toSwigInts(slots)
+	defer routingkit.DeleteIntVector(slotsVector)
+	sourcesVector := toSwigPoints(sources)
+	defer routingkit.DeletePointVector(sourcesVector)
+	targetsVector := toSwigPoints(targets)
+	defer routingkit.DeletePointVector(targetsVector)
+
+	cancelled, stop := cancellation(ctx)
+	computed := int(c.client.Many_to_many(
+		slotsVector,
+		c.snapRadius,
+		sourcesVector,
+		targetsVector,
+		uintBuffer(distances),
+		intBuffer(statuses),
+		uintBuffer(geoDistances),
+		uintBuffer(travelTimes),
+		intBuffer(cancelled),
+	))
+	stop()
+	runtime.KeepAlive(distances)
+	runtime.KeepAlive(statuses)
+	runtime.KeepAlive(geoDistances)
+	runtime.KeepAlive(travelTimes)
+	runtime.KeepAlive(cancelled)
+	if computed < len(sources) {
+		return computed, ctx.Err()
+	}
+	return computed, nil
+}
+
+// uintBuffer passes the slice to C++ as a buffer to write to, or a null
+// pointer if the slice is empty. The caller must keep the slice alive until
+// the call returns.
+func uintBuffer(s []uint32) routingkit.SWIGTYPE_p_unsigned_int {
+	if len(s) == 0 {
+		return routingkit.SwigcptrSWIGTYPE_p_unsigned_int(0)
+	}
+	return routingkit.SwigcptrSWIGTYPE_p_unsigned_int(uintptr(unsafe.Pointer(&s[0])))
+}
+
+// intBuffer is like uintBuffer, but for a slice of int32.
+func intBuffer(s []int32) routingkit.SWIGTYPE_p_int {
+	if len(s) == 0 {
+		return routingkit.SwigcptrSWIGTYPE_p_int(0)
+	}
+	return routingkit.SwigcptrSWIGTYPE_p_int(uintptr(unsafe.Pointer(&s[0])))
+}
+
+// floatBuffer is like uintBuffer, but for a slice of float32.
+func floatBuffer(s []float32) routingkit.SWIGTYPE_p_float {
+	if len(s) == 0 {
+		return routingkit.SwigcptrSWIGTYPE_p_float(0)
+	}
+	return routingkit.SwigcptrSWIGTYPE_p_float(uintptr(unsafe.Pointer(&s[0])))
+}
+
+// toRows splits cells stored row by row into rows of the given length.
+func toRows(cells []uint32, rows int, columns int) [][]uint32 {
+	matrix := make([][]uint32, rows)
+	for i := range matrix {
+		matrix[i] = cells[i*columns : (i+1)*columns : (i+1)*columns]
+	}
+	return matrix
+}
+
+// computedRows is like toRows, but only the first computed rows are set, and
+// the others are nil.
+func computedRows(cells []uint32, rows int, columns int, computed int) [][]uint32 {
+	matrix := make([][]uint32, rows)
+	copy(matrix, toRows(cells, computed, columns))
 	return matrix
 }
 
+// ComputeMatrixStatus is like ComputeMatrix, but additionally returns a matrix
+// holding the status of each cell, which tells why a cell is MaxDistance. Like
+// the matrix of costs, it is partial if ctx is done before it is complete.
+func (c client) ComputeMatrixStatus(
+	ctx context.Context,
+	sources []Point,
+	targets []Point,
+) ([][]uint32, [][]Status, error) {
+	if err := validatePoints(sources...); err != nil {
+		return nil, nil, err
+	}
+	if err := validatePoints(targets...); err != nil {
+		return nil, nil, err
+	}
+
+	n, m := len(sources), len(targets)
+	distances := make([]uint32, n*m)
+	cellStatuses := make([]int32, n*m)
+	computed, err := c.manyToMany(ctx, sources, targets, distances, cellStatuses, nil, nil)
+	statuses := make([][]Status, n)
+	for i := range statuses[:computed] {
+		statuses[i] = make([]Status, m)
+		for j := range statuses[i] {
+			statuses[i][j] = Status(cellStatuses[i*m+j])
+		}
+	}
+	return computedRows(distances, n, m, computed), statuses, err
+}
+
 // Distances returns a slice containing the minimum distances from the source to the
 // points in targets.
 func (c client) Distances(source []float32, targets [][]float32) []uint32 {
-	counter := <-c.channel
-	defer func() {
-		c.channel <- counter
-	}()
+	distances, _ := c.DistancesContext(context.Background(), source, targets)
+	return distances
+}
+
+// DistancesContext is like Distances, but gives up waiting for a free query
+// slot when ctx is done, in which case ctx.Err() is returned. It also returns an
+// error if a point is invalid.
+func (c client) DistancesContext(
+	ctx context.Context,
+	source []float32,
+	targets [][]float32,
+) ([]uint32, error) {
+	s, err := pointFromSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	t, err := pointsFromSlices(targets)
+	if err != nil {
+		return nil, err
+	}
+	return c.distances(ctx, HeadedPoint{Point: s}, headed(t))
+}
+
+// ComputeDistances is like DistancesContext, but takes the points as Points.
+func (c client) ComputeDistances(ctx context.Context, source Point, targets []Point) ([]uint32, error) {
+	if err := validatePoints(source); err != nil {
+		return nil, err
+	}
+	if err := validatePoints(targets...); err != nil {
+		return nil, err
+	}
+	return c.distances(ctx, HeadedPoint{Point: source}, headed(targets))
+}
+
+// ComputeHeadedDistances is like ComputeDistances, but snaps points with a
+// heading to roads in their direction of travel, like FindHeadedRoute.
+func (c client) ComputeHeadedDistances(
+	ctx context.Context,
+	source HeadedPoint,
+	targets []HeadedPoint,
+) ([]uint32, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	for _, t := range targets {
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return c.distances(ctx, source, targets)
+}
+
+func (c client) distances(ctx context.Context, source HeadedPoint, targets []HeadedPoint) ([]uint32, error) {
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(counter)
 
 	s := routingkit.NewPoint()
 	defer routingkit.DeletePoint(s)
-	s.SetLon(float32(source[0]))
-	s.SetLat(float32(source[1]))
-
-	targetsVector := routingkit.NewPointVector(int64(len(targets)))
+	s.SetLon(source.Point.Lon)
+	s.SetLat(source.Point.Lat)
+
+	points := make([]Point, len(targets))
+	headings := false
+	for i, t := range targets {
+		points[i] = t.Point
+		headings = headings || t.Tolerance > 0
+	}
+	// the headings of the targets are only passed on if there are any
+	bearings := routingkit.NewFloatVector()
+	defer routingkit.DeleteFloatVector(bearings)
+	tolerances := routingkit.NewFloatVector()
+	defer routingkit.DeleteFloatVector(tolerances)
+	if headings {
+		for _, t := range targets {
+			bearings.Add(t.Bearing)
+			tolerances.Add(t.Tolerance)
+		}
+	}
+	targetsVector := toSwigPoints(points)
 	defer routingkit.DeletePointVector(targetsVector)
 
-	for i := 0; i < len(targets); i++ {
-		t := routingkit.NewPoint()
-		t.SetLon(float32(targets[i][0]))
-		t.SetLat(float32(targets[i][1]))
-		targetsVector.Set(i, t)
+	distanceVec := c.client.Distances(
+		counter,
+		c.snapRadius,
+		s,
+		source.Bearing,
+		source.Tolerance,
+		targetsVector,
+		bearings,
+		tolerances,
+	)
+	defer routingkit.DeleteUnsignedVector(distanceVec)
+
+	return toUint32s(distanceVec), nil
+}
+
+func toUint32s(v routingkit.UnsignedVector) []uint32 {
+	values := make([]uint32, v.Size())
+	for i := range values {
+		values[i] = uint32(v.Get(i))
 	}
+	return values
+}
 
-	distanceVec := c.client.Distances(counter, float32(c.snapRadius), s, targetsVector)
-	defer routingkit.DeleteUnsignedVector(distanceVec)
-	numDistances := distanceVec.Size()
-	distances := make([]uint32, numDistances)
-	for i := 0; i < int(numDistances); i++ {
-		col := uint32(distanceVec.Get(i))
-		distances[i] = col
+// matrixWithMetrics creates two matrices representing the distances and
+// travel times of the routes minimizing the measure of the client from the
+// points in sources to the points in targets. Like MatrixContext, it stops
+// computing new rows once ctx is done.
+func (c client) matrixWithMetrics(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+) ([][]uint32, [][]uint32, error) {
+	s, err := pointsFromSlices(sources)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := pointsFromSlices(targets)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return distances
+	n, m := len(s), len(t)
+	distances := make([]uint32, n*m)
+	travelTimes := make([]uint32, n*m)
+	computed, err := c.manyToMany(ctx, s, t, make([]uint32, n*m), nil, distances, travelTimes)
+	return computedRows(distances, n, m, computed), computedRows(travelTimes, n, m, computed), err
+}
+
+// RouteWithTravelTime is like Route, but additionally returns the travel time
+// in milliseconds of the shortest route.
+func (c DistanceClient) RouteWithTravelTime(from []float32, to []float32) (uint32, uint32, [][]float32) {
+	distance, travelTime, waypoints, _ := c.RouteWithTravelTimeContext(context.Background(), from, to)
+	return distance, travelTime, waypoints
+}
+
+// RouteWithTravelTimeContext is like RouteWithTravelTime, but gives up waiting
+// for a free query slot when ctx is done, in which case ctx.Err() is returned.
+// It also returns an error if a point is invalid.
+func (c DistanceClient) RouteWithTravelTimeContext(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+) (uint32, uint32, [][]float32, error) {
+	result, err := c.findRoute(ctx, from, to, true)
+	if err != nil {
+		return MaxDistance, MaxDistance, nil, err
+	}
+	return result.Distance, result.TravelTime, toSlices(result.Waypoints), nil
+}
+
+// MatrixWithTravelTimes is like Matrix, but additionally returns a matrix
+// containing the travel times in milliseconds of the shortest routes.
+func (c DistanceClient) MatrixWithTravelTimes(sources [][]float32, targets [][]float32) ([][]uint32, [][]uint32) {
+	distances, travelTimes, _ := c.MatrixWithTravelTimesContext(context.Background(), sources, targets)
+	return distances, travelTimes
+}
+
+// MatrixWithTravelTimesContext is like MatrixWithTravelTimes, but stops
+// computing new rows once ctx is done. In that case the returned matrices are
+// partial: rows that were not computed are nil, and the returned error is
+// ctx.Err().
+func (c DistanceClient) MatrixWithTravelTimesContext(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+) ([][]uint32, [][]uint32, error) {
+	return c.matrixWithMetrics(ctx, sources, targets)
 }
 
 type TravelTimeClient struct {
@@ -409,36 +1148,17 @@ type TravelTimeClient struct {
 // NewTravelTimeClient initializes a TravelTimeClient using the provided .osm.pbf file and
 // .ch file. The .ch file will be created if it does not already exist. It is the caller's
 // responsibility to call Delete on the client when it is no longer needed.
-func NewTravelTimeClient(mapFile string, profile Profile) (TravelTimeClient, error) {
-	if _, err := os.Stat(mapFile); os.IsNotExist(err) {
-		return TravelTimeClient{}, fmt.Errorf("could not find map file at %v", mapFile)
-	}
-
-	allowedWayIDs, waySpeeds := parsePBF(mapFile, profile.Filter, profile.SpeedMapper)
-	chFile, err := chFileName(mapFile, profile, allowedWayIDs, waySpeeds, true)
+func NewTravelTimeClient(mapFile string, profile Profile, opts ...ClientOption) (TravelTimeClient, error) {
+	c, err := newClient(mapFile, profile, true, opts)
 	if err != nil {
 		return TravelTimeClient{}, err
 	}
+	return TravelTimeClient{client: c}, nil
+}
 
-	concurrentQueries := runtime.GOMAXPROCS(0)
-	var c routingkit.Client
-	withSwigProfile(profile, allowedWayIDs, waySpeeds, func(swigProfile routingkit.Profile) {
-		// sets that we are interested in the travel time rather than the distance
-		swigProfile.SetTravel_time(true)
-		c = routingkit.NewClient(concurrentQueries, mapFile, chFile, swigProfile)
-	})
-
-	channel := make(chan int, concurrentQueries)
-	for i := 0; i < concurrentQueries; i++ {
-		channel <- i
-	}
-
-	return TravelTimeClient{
-		client: client{
-			client:     c,
-			channel:    channel,
-			snapRadius: 1000,
-		}}, nil
+// Delete deletes the client, releasing memory allocated for C++ routing data structures
+func (c TravelTimeClient) Delete() {
+	c.client.Delete()
 }
 
 // Route finds the fastest route between the two points, returning the total route
@@ -471,6 +1191,181 @@ func (c TravelTimeClient) TravelTimes(source []float32, targets [][]float32) []u
 	return c.client.Distances(source, targets)
 }
 
+// RouteContext is like Route, but gives up waiting for a free query slot when
+// ctx is done, in which case ctx.Err() is returned. It also returns an error if
+// a point is invalid.
+func (c TravelTimeClient) RouteContext(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+) (uint32, [][]float32, error) {
+	return c.client.RouteContext(ctx, from, to)
+}
+
+// TravelTimeContext is like TravelTime, but gives up waiting for a free query
+// slot when ctx is done, in which case ctx.Err() is returned. It also returns an
+// error if a point is invalid.
+func (c TravelTimeClient) TravelTimeContext(ctx context.Context, from []float32, to []float32) (uint32, error) {
+	return c.client.DistanceContext(ctx, from, to)
+}
+
+// NearestContext is like Nearest, but gives up waiting for a free query slot
+// when ctx is done, in which case ctx.Err() is returned. It also returns an
+// error if the point is invalid.
+func (c TravelTimeClient) NearestContext(ctx context.Context, point []float32) ([]float32, bool, error) {
+	return c.client.NearestContext(ctx, point)
+}
+
+// MatrixContext is like Matrix, but stops computing new rows once ctx is done.
+// In that case the returned matrix is partial: rows that were not computed are
+// nil, and the returned error is ctx.Err(). A nil error means the matrix is
+// complete. If a point is invalid, no matrix is computed and an error is
+// returned.
+func (c TravelTimeClient) MatrixContext(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+) ([][]uint32, error) {
+	return c.client.MatrixContext(ctx, sources, targets)
+}
+
+// TravelTimesContext is like TravelTimes, but gives up waiting for a free query
+// slot when ctx is done, in which case ctx.Err() is returned. It also returns an
+// error if a point is invalid.
+func (c TravelTimeClient) TravelTimesContext(
+	ctx context.Context,
+	source []float32,
+	targets [][]float32,
+) ([]uint32, error) {
+	return c.client.DistancesContext(ctx, source, targets)
+}
+
+// RouteWithDistance is like Route, but additionally returns the distance in
+// meters of the fastest route.
+func (c TravelTimeClient) RouteWithDistance(from []float32, to []float32) (uint32, uint32, [][]float32) {
+	travelTime, distance, waypoints, _ := c.RouteWithDistanceContext(context.Background(), from, to)
+	return travelTime, distance, waypoints
+}
+
+// RouteWithDistanceContext is like RouteWithDistance, but gives up waiting for
+// a free query slot when ctx is done, in which case ctx.Err() is returned. It
+// also returns an error if a point is invalid.
+func (c TravelTimeClient) RouteWithDistanceContext(
+	ctx context.Context,
+	from []float32,
+	to []float32,
+) (uint32, uint32, [][]float32, error) {
+	result, err := c.client.findRoute(ctx, from, to, true)
+	if err != nil {
+		return MaxDistance, MaxDistance, nil, err
+	}
+	return result.TravelTime, result.Distance, toSlices(result.Waypoints), nil
+}
+
+// MatrixWithDistances is like Matrix, but additionally returns a matrix
+// containing the distances in meters of the fastest routes.
+func (c TravelTimeClient) MatrixWithDistances(sources [][]float32, targets [][]float32) ([][]uint32, [][]uint32) {
+	travelTimes, distances, _ := c.MatrixWithDistancesContext(context.Background(), sources, targets)
+	return travelTimes, distances
+}
+
+// MatrixWithDistancesContext is like MatrixWithDistances, but stops computing
+// new rows once ctx is done. In that case the returned matrices are partial:
+// rows that were not computed are nil, and the returned error is ctx.Err().
+func (c TravelTimeClient) MatrixWithDistancesContext(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+) ([][]uint32, [][]uint32, error) {
+	distances, travelTimes, err := c.client.matrixWithMetrics(ctx, sources, targets)
+	return travelTimes, distances, err
+}
+
+// FindRoute finds the fastest route between the two points. It returns an
+// error if a point is invalid or if ctx is done before a free query slot
+// becomes available.
+func (c TravelTimeClient) FindRoute(ctx context.Context, from Point, to Point) (RouteResult, error) {
+	return c.client.FindRoute(ctx, from, to)
+}
+
+// FindNearest returns the nearest point in the road network within the radius
+// configured on the client. The second return value is false if no point could
+// be found. It returns an error if the point is invalid or if ctx is done
+// before a free query slot becomes available.
+func (c TravelTimeClient) FindNearest(ctx context.Context, point Point) (Point, bool, error) {
+	return c.client.FindNearest(ctx, point)
+}
+
+// Snap snaps the point to the nearest point in the road network within the
+// radius configured on the client and describes the way it was snapped to. The
+// second return value is false if no point could be found. It returns an error
+// if the point is invalid or if ctx is done before a free query slot becomes
+// available.
+func (c TravelTimeClient) Snap(ctx context.Context, point Point) (SnapResult, bool, error) {
+	return c.client.Snap(ctx, point)
+}
+
+// ComputeMatrix is like MatrixContext, but takes the points as Points. There
+// is no matrix of HeadedPoints: if the headings matter, ComputeHeadedDistances
+// computes the row of each source.
+func (c TravelTimeClient) ComputeMatrix(ctx context.Context, sources []Point, targets []Point) ([][]uint32, error) {
+	return c.client.ComputeMatrix(ctx, sources, targets)
+}
+
+// MatrixInto is like Matrix, but writes the travel times row by row into dst
+// instead of allocating a matrix: the travel time from sources[i] to
+// targets[j] is stored in dst[i*len(targets)+j]. It returns an error if a
+// point is invalid or if dst holds less than len(sources)*len(targets)
+// elements.
+func (c TravelTimeClient) MatrixInto(dst []uint32, sources [][]float32, targets [][]float32) error {
+	return c.client.MatrixInto(dst, sources, targets)
+}
+
+// MatrixIntoContext is like MatrixInto, but stops computing new rows once ctx
+// is done, in which case the rows that were not computed are left unchanged in
+// dst and ctx.Err() is returned.
+func (c TravelTimeClient) MatrixIntoContext(ctx context.Context, dst []uint32, sources [][]float32, targets [][]float32) error {
+	return c.client.MatrixIntoContext(ctx, dst, sources, targets)
+}
+
+// ComputeMatrixInto is like MatrixIntoContext, but takes the points as Points.
+func (c TravelTimeClient) ComputeMatrixInto(ctx context.Context, dst []uint32, sources []Point, targets []Point) error {
+	return c.client.ComputeMatrixInto(ctx, dst, sources, targets)
+}
+
+// ComputeMatrixStatus is like ComputeMatrix, but additionally returns a matrix
+// holding the status of each cell, which tells why a cell is MaxDistance. Like
+// the matrix of costs, it is partial if ctx is done before it is complete.
+func (c TravelTimeClient) ComputeMatrixStatus(
+	ctx context.Context,
+	sources []Point,
+	targets []Point,
+) ([][]uint32, [][]Status, error) {
+	return c.client.ComputeMatrixStatus(ctx, sources, targets)
+}
+
+// ComputeTravelTimes is like TravelTimesContext, but takes the points as
+// Points.
+func (c TravelTimeClient) ComputeTravelTimes(ctx context.Context, source Point, targets []Point) ([]uint32, error) {
+	return c.client.ComputeDistances(ctx, source, targets)
+}
+
+// ComputeHeadedTravelTimes is like ComputeTravelTimes, but snaps points with a
+// heading to roads in their direction of travel.
+func (c TravelTimeClient) ComputeHeadedTravelTimes(
+	ctx context.Context,
+	source HeadedPoint,
+	targets []HeadedPoint,
+) ([]uint32, error) {
+	return c.client.ComputeHeadedDistances(ctx, source, targets)
+}
+
+// FindHeadedRoute is like FindRoute, but snaps points with a heading to roads
+// in their direction of travel.
+func (c TravelTimeClient) FindHeadedRoute(ctx context.Context, from HeadedPoint, to HeadedPoint) (RouteResult, error) {
+	return c.client.FindHeadedRoute(ctx, from, to)
+}
+
 // SetSnapRadius updates Client so that all queries will snap points to the nearest
 // street network point within the given radius in meters.
 func (c *TravelTimeClient) SetSnapRadius(n float32) {
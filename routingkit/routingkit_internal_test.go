@@ -218,3 +218,189 @@ var floatComparer = cmp.Comparer(func(x, y float64) bool {
 	}
 	return (diff / mean) < 0.00001
 })
+
+func TestDirections(t *testing.T) {
+	a := Point{Lon: 0, Lat: 0}
+	b := Point{Lon: 0, Lat: 0.001}
+	c := Point{Lon: 0.001, Lat: 0.001}
+	d := Point{Lon: 0.0015, Lat: 0.0015}
+	e := Point{Lon: 0.002, Lat: 0.001}
+	f := Point{Lon: 0.002, Lat: 0}
+	route := RouteResult{
+		Segments: []Segment{
+			{Name: "North Street", From: a, To: b, StartBearing: 0, EndBearing: 0, Distance: 111, Duration: 10, Branches: 2},
+			{Name: "East Street", Ref: "E 1", From: b, To: c, StartBearing: 90, EndBearing: 90, Distance: 111, Duration: 10, Branches: 2},
+			{Roundabout: true, From: c, To: d, StartBearing: 45, EndBearing: 45, Distance: 70, Duration: 7, Branches: 2},
+			{Roundabout: true, From: d, To: e, StartBearing: 135, EndBearing: 135, Distance: 70, Duration: 7, Branches: 2},
+			{Name: "South Street", From: e, To: f, StartBearing: 180, EndBearing: 180, Distance: 111, Duration: 10},
+		},
+	}
+
+	got := route.Directions()
+	for i := range got {
+		got[i].Angle = math.Round(got[i].Angle)
+		got[i].Bearing = math.Round(got[i].Bearing)
+	}
+	expected := []Maneuver{
+		{
+			Type:        ManeuverDepart,
+			Road:        "North Street",
+			Location:    a,
+			Distance:    111,
+			Duration:    10,
+			Instruction: "Head north on North Street",
+		},
+		{
+			Type:        ManeuverTurn,
+			Modifier:    ModifierRight,
+			Angle:       90,
+			Bearing:     90,
+			Road:        "East Street",
+			Ref:         "E 1",
+			Location:    b,
+			Distance:    111,
+			Duration:    10,
+			Instruction: "Turn right onto East Street",
+		},
+		{
+			Type:        ManeuverRoundabout,
+			Angle:       -45,
+			Bearing:     45,
+			Exit:        2,
+			Road:        "South Street",
+			Location:    c,
+			Distance:    251,
+			Duration:    24,
+			Instruction: "At the roundabout, take the 2nd exit onto South Street",
+		},
+		{
+			Type:        ManeuverArrive,
+			Location:    f,
+			Instruction: "Arrive at your destination",
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected directions (-want +got):\n%s", diff)
+	}
+
+	// the turn angle is taken from the directions of travel at the junction,
+	// not from the straight lines between the ends of the segments: the first
+	// road curves to the east, so following the second one goes straight on
+	curved := RouteResult{
+		Segments: []Segment{
+			{Name: "Curved Street", From: a, To: c, StartBearing: 0, EndBearing: 90, Distance: 200, Duration: 20},
+			{Name: "East Street", From: c, To: e, StartBearing: 90, EndBearing: 90, Distance: 111, Duration: 10},
+		},
+	}
+	got = curved.Directions()
+	if len(got) != 3 || got[1].Type != ManeuverContinue || got[1].Angle != 0 || got[0].Bearing != 0 {
+		t.Errorf("expected to head north and continue straight, got %+v", got)
+	}
+
+	// roads without a name are told apart by their way or, between ways, by
+	// their highway class and the turn angle
+	unnamed := RouteResult{
+		Segments: []Segment{
+			{WayID: 1, Highway: "service", From: a, To: b, StartBearing: 0, EndBearing: 0, Distance: 111, Duration: 10},
+			{WayID: 1, Highway: "service", From: b, To: c, StartBearing: 90, EndBearing: 90, Distance: 111, Duration: 10},
+			{WayID: 2, Highway: "service", From: c, To: e, StartBearing: 95, EndBearing: 90, Distance: 111, Duration: 10},
+			{WayID: 3, Highway: "track", From: e, To: f, StartBearing: 90, EndBearing: 90, Distance: 111, Duration: 10},
+			{WayID: 4, Highway: "track", From: f, To: a, StartBearing: 180, EndBearing: 180, Distance: 111, Duration: 10},
+		},
+	}
+	got = unnamed.Directions()
+	var types []ManeuverType
+	for _, m := range got {
+		types = append(types, m.Type)
+	}
+	expectedTypes := []ManeuverType{ManeuverDepart, ManeuverContinue, ManeuverTurn, ManeuverArrive}
+	if !cmp.Equal(expectedTypes, types) || got[0].Distance != 333 {
+		t.Errorf("expected to depart for 333 m, continue and turn, got %+v", got)
+	}
+
+	if got := (RouteResult{}).Directions(); got != nil {
+		t.Errorf("expected no directions without segments, got %v", got)
+	}
+}
+
+func TestModifier(t *testing.T) {
+	tests := []struct {
+		angle    float64
+		expected Modifier
+	}{
+		{angle: 0, expected: ModifierStraight},
+		{angle: -19, expected: ModifierStraight},
+		{angle: 30, expected: ModifierSlightRight},
+		{angle: -30, expected: ModifierSlightLeft},
+		{angle: 90, expected: ModifierRight},
+		{angle: -90, expected: ModifierLeft},
+		{angle: 150, expected: ModifierSharpRight},
+		{angle: -150, expected: ModifierSharpLeft},
+		{angle: 180, expected: ModifierUTurn},
+	}
+	for i, test := range tests {
+		if got := modifier(test.angle); got != test.expected {
+			t.Errorf("[%d] expected %v, got %v", i, test.expected, got)
+		}
+	}
+
+	for n, expected := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 21: "21st"} {
+		if got := ordinal(n); got != expected {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestContour(t *testing.T) {
+	tests := []struct {
+		name     string
+		cells    []vertex
+		expected [][][]vertex
+	}{
+		{
+			name:     "no cells",
+			expected: [][][]vertex{},
+		},
+		{
+			name:  "single cell",
+			cells: []vertex{{0, 0}},
+			expected: [][][]vertex{
+				{{{1, 0}, {1, 1}, {0, 1}, {0, 0}, {1, 0}}},
+			},
+		},
+		{
+			name:  "straight sides",
+			cells: []vertex{{0, 0}, {1, 0}},
+			expected: [][][]vertex{
+				{{{2, 0}, {2, 1}, {0, 1}, {0, 0}, {2, 0}}},
+			},
+		},
+		{
+			name:  "touching corners",
+			cells: []vertex{{0, 0}, {1, 1}},
+			expected: [][][]vertex{
+				{{{1, 0}, {1, 1}, {0, 1}, {0, 0}, {1, 0}}},
+				{{{2, 1}, {2, 2}, {1, 2}, {1, 1}, {2, 1}}},
+			},
+		},
+		{
+			name:  "hole",
+			cells: []vertex{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {2, 1}, {0, 2}, {1, 2}, {2, 2}},
+			expected: [][][]vertex{
+				{
+					{{3, 0}, {3, 3}, {0, 3}, {0, 0}, {3, 0}},
+					{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		cells := map[vertex]bool{}
+		for _, c := range test.cells {
+			cells[c] = true
+		}
+		if diff := cmp.Diff(test.expected, contour(cells), cmp.AllowUnexported(vertex{})); diff != "" {
+			t.Errorf("%s: unexpected polygons (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
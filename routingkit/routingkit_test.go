@@ -1,17 +1,27 @@
 package routingkit_test
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"log"
 	"math"
 	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nextmv-io/go-routingkit/routingkit"
@@ -142,15 +152,193 @@ func TestCreateCH(t *testing.T) {
 	}
 	defer os.Remove(chFile)
 
-	_, err = routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	_, err = routingkit.NewDistanceClient(marylandMap, routingkit.Car(), routingkit.WithCHPath(chFile))
 	if err != nil {
 		t.Fatalf("creating Client: %v", err)
 	}
-	if os.Stat(chFile); err != nil {
+	if _, err := os.Stat(chFile); err != nil {
 		t.Errorf("expected ch file to be created, but got error stating file: %v", err)
 	}
 }
 
+func TestClientOptions(t *testing.T) {
+	dir, err := os.MkdirTemp("", "routingkit_test")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var logs strings.Builder
+	cli, err := routingkit.NewDistanceClient(
+		marylandMap,
+		routingkit.Car(),
+		routingkit.WithCacheDir(dir),
+		routingkit.WithConcurrency(2),
+		routingkit.WithSnapRadius(10),
+		routingkit.WithLogger(log.New(&logs, "", 0)),
+	)
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+
+	chFiles, err := filepath.Glob(filepath.Join(dir, "maryland.osm.pbf_car_distance_*.ch"))
+	if err != nil || len(chFiles) != 1 {
+		t.Errorf("expected one ch file in cache dir, got %v (%v)", chFiles, err)
+	}
+	if !strings.Contains(logs.String(), "building contraction hierarchy") {
+		t.Errorf("expected log about building the contraction hierarchy, got %q", logs.String())
+	}
+	// this point is further than 10m away from the street network
+	if _, ok := cli.Nearest([]float32{-76.584897, 39.280774}); ok {
+		t.Errorf("expected point not to be snapped with a snap radius of 10m")
+	}
+	cli.SetSnapRadius(1000)
+	got := cli.Matrix(
+		[][]float32{{-76.587490, 39.299710}, {-76.594045, 39.300524}, {-76.586664, 39.290938}},
+		[][]float32{{-76.582855, 39.309095}, {-76.599388, 39.302014}},
+	)
+	expected := [][]uint32{{1496, 1259}, {1831, 575}, {2372, 2224}}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	invalid := []routingkit.ClientOption{
+		routingkit.WithConcurrency(0),
+		routingkit.WithSnapRadius(-1),
+		routingkit.WithCHPath(""),
+		routingkit.WithCacheDir(""),
+		routingkit.WithAlternativeLimits(0.9, 0.5),
+		routingkit.WithAlternativeLimits(1.25, 1.5),
+		routingkit.WithIsochroneCellSize(0),
+		routingkit.WithMapMatching(0, 20),
+		routingkit.WithMapMatching(10, -1),
+	}
+	for i, opt := range invalid {
+		if _, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car(), opt); err == nil {
+			t.Errorf("[%d] expected an error for an invalid option", i)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir, err := os.MkdirTemp("", "routingkit_test")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a map file that is not a valid .osm.pbf file
+	corruptMap := filepath.Join(dir, "corrupt.osm.pbf")
+	if err := os.WriteFile(corruptMap, []byte("not a pbf file"), 0644); err != nil {
+		t.Fatalf("writing corrupt map: %v", err)
+	}
+	if _, err := routingkit.NewDistanceClient(corruptMap, routingkit.Car()); err == nil {
+		t.Errorf("expected an error for a corrupt map file")
+	}
+	if _, err := routingkit.NewTravelTimeClient(corruptMap, routingkit.Car()); err == nil {
+		t.Errorf("expected an error for a corrupt map file")
+	}
+
+	// a valid map file with a corrupt contraction hierarchy next to it
+	data, err := os.ReadFile(marylandMap)
+	if err != nil {
+		t.Fatalf("reading map: %v", err)
+	}
+	validMap := filepath.Join(dir, "maryland.osm.pbf")
+	if err := os.WriteFile(validMap, data, 0644); err != nil {
+		t.Fatalf("writing map: %v", err)
+	}
+	cli, err := routingkit.NewDistanceClient(validMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	cli.Delete()
+	chFiles, err := filepath.Glob(filepath.Join(dir, "*.ch"))
+	if err != nil || len(chFiles) != 1 {
+		t.Fatalf("expected one ch file, got %v (%v)", chFiles, err)
+	}
+	if err := os.WriteFile(chFiles[0], []byte("not a contraction hierarchy"), 0644); err != nil {
+		t.Fatalf("writing corrupt ch: %v", err)
+	}
+	if _, err := routingkit.NewDistanceClient(validMap, routingkit.Car()); err == nil {
+		t.Errorf("expected an error for a corrupt contraction hierarchy")
+	}
+}
+
+// nilPointer is dereferenced to check that the crash handlers leave faults in
+// Go code to the Go runtime.
+var nilPointer *int
+
+// TestCrashDiagnostics runs itself in a subprocess that installs the crash
+// handlers and then aborts, and checks that a stack trace was written.
+func TestCrashDiagnostics(t *testing.T) {
+	if out := os.Getenv("ROUTINGKIT_CRASH_DIAGNOSTICS"); out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			t.Fatalf("creating diagnostics file: %v", err)
+		}
+		var w io.Writer = file
+		if os.Getenv("ROUTINGKIT_CRASH_DIAGNOSTICS_PIPE") != "" {
+			// hide the file, so that the diagnostics are forwarded
+			w = struct{ io.Writer }{file}
+		}
+		_, err = routingkit.NewDistanceClient(
+			marylandMap,
+			routingkit.Car(),
+			routingkit.WithCrashDiagnostics(w),
+		)
+		if err != nil {
+			t.Fatalf("creating Client: %v", err)
+		}
+		// faults in Go code are still turned into panics
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected nil pointer dereference to panic")
+				}
+			}()
+			_ = *nilPointer
+		}()
+		// unlike syscall.Kill, the signal is raised outside of Go code, like
+		// in a crash of the routing code, so that other goroutines keep
+		// running while the handler runs
+		_, _, errno := syscall.Syscall(syscall.SYS_KILL, uintptr(os.Getpid()), uintptr(syscall.SIGABRT), 0)
+		if errno != 0 {
+			t.Fatalf("sending signal: %v", errno)
+		}
+		time.Sleep(10 * time.Second)
+		t.Fatalf("process was not terminated")
+	}
+
+	for _, pipe := range []string{"", "1"} {
+		out, err := tempFile("", "routingkit_crash")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(out)
+
+		cmd := exec.Command(os.Args[0], "-test.run=^TestCrashDiagnostics$", "-clean_ch=false")
+		cmd.Env = append(
+			os.Environ(),
+			"ROUTINGKIT_CRASH_DIAGNOSTICS="+out,
+			"ROUTINGKIT_CRASH_DIAGNOSTICS_PIPE="+pipe,
+		)
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Fatalf("expected subprocess to crash")
+		}
+		diagnostics, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("reading diagnostics: %v", err)
+		}
+		expected := fmt.Sprintf("Error: signal %d:", syscall.SIGABRT)
+		if !strings.HasPrefix(string(diagnostics), expected) {
+			t.Errorf("[pipe=%q] expected diagnostics starting with %q, got %q (output %q)", pipe, expected, diagnostics, output)
+		}
+	}
+}
+
 func TestShrunkMatrix(t *testing.T) {
 	tests := []struct {
 		sources      [][]float32
@@ -278,176 +466,1556 @@ func TestNearest(t *testing.T) {
 		if ok != test.expectedSnap {
 			t.Errorf("[%d] expected snap=%t, snapped=%t", i, test.expectedSnap, ok)
 		}
-		if !reflect.DeepEqual(got, test.expected) {
-			t.Errorf("[%d] expected %v, got %v", i, test.expected, got)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("[%d] expected %v, got %v", i, test.expected, got)
+		}
+	}
+}
+
+func TestDistances(t *testing.T) {
+	tests := []struct {
+		source       []float32
+		destinations [][]float32
+		snap         float32
+		profile      routingkit.Profile
+		ch           string
+
+		expected []uint32
+	}{
+		{
+			source: []float32{-76.587490, 39.299710},
+			destinations: [][]float32{
+				{-76.582855, 39.309095},
+				{-76.591286, 39.298443},
+			},
+			snap:    1000,
+			profile: routingkit.Car(),
+
+			expected: []uint32{1496, 508},
+		},
+		{
+			source: []float32{-76.587490, 39.299710},
+			destinations: [][]float32{
+				{-76.582855, 39.309095},
+				{-76.591286, 39.298443},
+			},
+			snap:    1000,
+			profile: routingkit.Bike(),
+
+			expected: []uint32{1440, 617},
+		},
+		{
+			source: []float32{-76.587490, 39.299710},
+			destinations: [][]float32{
+				{-76.582855, 39.309095},
+				{-76.591286, 39.298443},
+			},
+			snap:    1000,
+			profile: routingkit.Pedestrian(),
+
+			expected: []uint32{1429, 428},
+		},
+		{
+			// should receive MaxDistance for invalid destinations
+			source: []float32{-76.587490, 39.299710},
+			destinations: [][]float32{
+				{-76.60548, 39.30772},
+				{-76.582855, 39.309095},
+				{-76.584897, 39.280774},
+				{-76.599388, 39.302014},
+			},
+			snap:    100,
+			profile: routingkit.Car(),
+
+			expected: []uint32{routingkit.MaxDistance, 1496, routingkit.MaxDistance, 1259},
+		},
+		{
+			// invalid source - should receive all MaxDistance
+			source: []float32{-76.60586, 39.30228},
+			destinations: [][]float32{
+				{-76.60548, 39.30772},
+				{-76.584897, 39.280774},
+			},
+			snap:    10,
+			profile: routingkit.Car(),
+
+			expected: []uint32{routingkit.MaxDistance, routingkit.MaxDistance},
+		},
+	}
+
+	for i, test := range tests {
+		cli, err := routingkit.NewDistanceClient(marylandMap, test.profile)
+		if err != nil {
+			t.Fatalf("creating Client: %v", err)
+		}
+		cli.SetSnapRadius(test.snap)
+		got := cli.Distances(test.source, test.destinations)
+		if !reflect.DeepEqual(test.expected, got) {
+			t.Errorf("[%d] expected %v, got %v", i, test.expected, got)
+		}
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	tests := []struct {
+		sources      [][]float32
+		destinations [][]float32
+		profile      routingkit.Profile
+		ch           string
+
+		expected [][]uint32
+	}{
+		{
+			sources: [][]float32{
+				{-76.587490, 39.299710},
+				{-76.594045, 39.300524},
+				{-76.586664, 39.290938},
+				{-76.598423, 39.289484},
+			},
+			destinations: [][]float32{
+				{-76.582855, 39.309095},
+				{-76.599388, 39.302014},
+			},
+			expected: [][]uint32{
+				{1496, 1259},
+				{1831, 575},
+				{2372, 2224},
+				{3399, 1548},
+			},
+			profile: routingkit.Car(),
+		},
+		{
+			sources: [][]float32{
+				{-76.587490, 39.299710},
+				{-76.594045, 39.300524},
+				{-76.586664, 39.290938},
+				{-76.598423, 39.289484},
+			},
+			destinations: [][]float32{
+				{-76.582855, 39.309095},
+				{-76.599388, 39.302014},
+			},
+			expected: [][]uint32{
+				{1440, 1259},
+				{1796, 575},
+				{2372, 2216},
+				{3364, 1548},
+			},
+			profile: routingkit.Bike(),
+		},
+		{
+			sources: [][]float32{
+				{-76.587490, 39.299710},
+				{-76.594045, 39.300524},
+				{-76.586664, 39.290938},
+				{-76.598423, 39.289484},
+			},
+			destinations: [][]float32{
+				{-76.582855, 39.309095},
+				{-76.599388, 39.302014},
+			},
+			expected: [][]uint32{
+				{1429, 1242},
+				{1589, 558},
+				{2367, 2189},
+				{3151, 1533},
+			},
+			profile: routingkit.Pedestrian(),
+		},
+	}
+
+	for i, test := range tests {
+		cli, err := routingkit.NewDistanceClient(marylandMap, test.profile)
+		if err != nil {
+			t.Fatalf("creating Client: %v", err)
+		}
+		got := cli.Matrix(test.sources, test.destinations)
+		if !reflect.DeepEqual(test.expected, got) {
+			t.Errorf("[%d] expected %v, got %v", i, test.expected, got)
+		}
+	}
+}
+
+func TestMatrixContext(t *testing.T) {
+	sources := [][]float32{
+		{-76.587490, 39.299710},
+		{-76.594045, 39.300524},
+	}
+	destinations := [][]float32{
+		{-76.582855, 39.309095},
+		{-76.599388, 39.302014},
+	}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+
+	got, err := cli.MatrixContext(context.Background(), sources, destinations)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := [][]uint32{{1496, 1259}, {1831, 575}}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = cli.MatrixContext(ctx, sources, destinations)
+	if err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+	if !reflect.DeepEqual([][]uint32{nil, nil}, got) {
+		t.Errorf("expected no computed rows, got %v", got)
+	}
+
+	// a matrix with many rows is still being computed when the deadline
+	// passes, so only a prefix of its rows is returned
+	many := make([][]float32, 100000)
+	for i := range many {
+		many[i] = sources[i%len(sources)]
+	}
+	deadline, cancelDeadline := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelDeadline()
+	got, err = cli.MatrixContext(deadline, many, destinations)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+	if len(got) != len(many) {
+		t.Fatalf("expected %d rows, got %d", len(many), len(got))
+	}
+	computed := 0
+	for computed < len(got) && got[computed] != nil {
+		if !reflect.DeepEqual(expected[computed%len(expected)], got[computed]) {
+			t.Errorf("row %d: expected %v, got %v", computed, expected[computed%len(expected)], got[computed])
+		}
+		computed++
+	}
+	if computed == len(got) {
+		t.Errorf("expected the computation to stop before the last row")
+	}
+	for i := computed; i < len(got); i++ {
+		if got[i] != nil {
+			t.Fatalf("expected row %d after the first uncomputed row to be nil, got %v", i, got[i])
+		}
+	}
+
+	if _, err := cli.DistanceContext(ctx, sources[0], destinations[0]); err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+	if _, _, err := cli.RouteContext(ctx, sources[0], destinations[0]); err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestMatrixInto(t *testing.T) {
+	sources := [][]float32{
+		{-76.587490, 39.299710},
+		{-76.594045, 39.300524},
+	}
+	destinations := [][]float32{
+		{-76.582855, 39.309095},
+		{-76.599388, 39.302014},
+	}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+
+	// the cells beyond the matrix are left alone
+	dst := []uint32{0, 0, 0, 0, 7}
+	if err := cli.MatrixInto(dst, sources, destinations); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []uint32{1496, 1259, 1831, 575, 7}
+	if !reflect.DeepEqual(expected, dst) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+
+	if err := cli.MatrixInto(make([]uint32, 3), sources, destinations); err == nil {
+		t.Errorf("expected error for a buffer that is too small")
+	}
+	if err := cli.MatrixInto(dst, [][]float32{{-76.587490}}, destinations); err == nil {
+		t.Errorf("expected error for an invalid point")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dst = make([]uint32, 4)
+	if err := cli.MatrixIntoContext(ctx, dst, sources, destinations); err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+	if !reflect.DeepEqual(make([]uint32, 4), dst) {
+		t.Errorf("expected buffer to be unchanged, got %v", dst)
+	}
+}
+
+func TestStreamMatrix(t *testing.T) {
+	points := [][]float32{
+		{-76.587490, 39.299710},
+		{-76.594045, 39.300524},
+		{-76.582855, 39.309095},
+		{-76.599388, 39.302014},
+		{-76.0, 39.0},
+	}
+	// enough sources for several batches of rows
+	sources := make([][]float32, 150)
+	for i := range sources {
+		sources[i] = points[i%len(points)]
+	}
+	targets := points
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+	expected := cli.Matrix(sources, targets)
+
+	var rows [][]uint32
+	err = cli.StreamMatrix(ctx, sources, targets, func(row int, costs []uint32) error {
+		if row != len(rows) {
+			return fmt.Errorf("expected row %d, got %d", len(rows), row)
+		}
+		rows = append(rows, costs)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Errorf("expected streamed rows to match Matrix, got %v", rows)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = cli.StreamMatrix(ctx, sources, targets, func(row int, costs []uint32) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected error %v after one row, got %v after %d rows", stop, err, calls)
+	}
+
+	var buf bytes.Buffer
+	if err := cli.WriteMatrix(ctx, &buf, sources, targets, routingkit.MatrixNDJSON); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	rows = nil
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var row []uint32
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("decoding row %d: %v", len(rows), err)
+		}
+		rows = append(rows, row)
+	}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Errorf("expected NDJSON rows to match Matrix, got %v", rows)
+	}
+
+	buf.Reset()
+	if err := cli.WriteMatrix(ctx, &buf, sources, targets, routingkit.MatrixBinary); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cells := make([]uint32, len(sources)*len(targets))
+	if err := binary.Read(&buf, binary.LittleEndian, cells); err != nil {
+		t.Fatalf("decoding binary matrix: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected %d cells, got %d trailing bytes", len(cells), buf.Len())
+	}
+	for i, row := range expected {
+		if got := cells[i*len(targets) : (i+1)*len(targets)]; !reflect.DeepEqual(row, got) {
+			t.Errorf("expected binary row %d to be %v, got %v", i, row, got)
+		}
+	}
+
+	if err := cli.WriteMatrix(ctx, &buf, sources, targets, "csv"); err == nil {
+		t.Errorf("expected error for an unknown format")
+	}
+	if err := cli.StreamMatrix(ctx, [][]float32{{-76.587490}}, targets, nil); err == nil {
+		t.Errorf("expected error for an invalid point")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = cli.StreamMatrix(canceled, sources, targets, func(int, []uint32) error { return nil })
+	if err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestMatrixWithin(t *testing.T) {
+	points := [][]float32{
+		{-76.587490, 39.299710},
+		{-76.594045, 39.300524},
+		{-76.582855, 39.309095},
+		{-76.599388, 39.302014},
+		{-76.0, 39.0},
+	}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+	matrix := cli.Matrix(points, points)
+
+	for _, maxCost := range []uint32{0, 1000, 1500, routingkit.MaxDistance} {
+		expected := []routingkit.SparseCell{}
+		for i, row := range matrix {
+			for j, cost := range row {
+				if cost <= maxCost && cost != routingkit.MaxDistance {
+					expected = append(expected, routingkit.SparseCell{Source: i, Target: j, Cost: cost})
+				}
+			}
+		}
+		cells, err := cli.MatrixWithin(ctx, points, points, maxCost)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(expected, cells) {
+			t.Errorf("expected cells %v within %d, got %v", expected, maxCost, cells)
+		}
+	}
+
+	cells, err := cli.DistancesWithin(ctx, points[1], points, 1000)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []routingkit.SparseCell{
+		{Source: 0, Target: 0, Cost: 824},
+		{Source: 0, Target: 1, Cost: 0},
+		{Source: 0, Target: 3, Cost: 575},
+	}
+	if !reflect.DeepEqual(expected, cells) {
+		t.Errorf("expected cells %v, got %v", expected, cells)
+	}
+
+	if _, err := cli.MatrixWithin(ctx, [][]float32{{-76.587490}}, points, 1000); err == nil {
+		t.Errorf("expected error for an invalid point")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := cli.MatrixWithin(canceled, points, points, 1000); err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestFacilityIndex(t *testing.T) {
+	points := []routingkit.Point{
+		{Lon: -76.587490, Lat: 39.299710},
+		{Lon: -76.594045, Lat: 39.300524},
+		{Lon: -76.582855, Lat: 39.309095},
+		{Lon: -76.599388, Lat: 39.302014},
+		{Lon: -76.0, Lat: 39.0},
+	}
+	slices := make([][]float32, len(points))
+	for i, p := range points {
+		slices[i] = []float32{p.Lon, p.Lat}
+	}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+	matrix := cli.Matrix(slices, slices)
+
+	index, err := cli.NewFacilityIndex(ctx, points)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer index.Delete()
+	if index.Len() != len(points) {
+		t.Errorf("expected %d facilities, got %d", len(points), index.Len())
+	}
+
+	tests := []struct {
+		k       int
+		maxCost uint32
+	}{
+		{k: 0, maxCost: routingkit.MaxDistance},
+		{k: 1, maxCost: routingkit.MaxDistance},
+		{k: 2, maxCost: routingkit.MaxDistance},
+		{k: 10, maxCost: routingkit.MaxDistance},
+		{k: 10, maxCost: 1000},
+		{k: 2, maxCost: 0},
+	}
+	for _, test := range tests {
+		for i, p := range points {
+			// the facilities reachable within the limit, by cost and index
+			expected := []routingkit.NearbyFacility{}
+			for j, cost := range matrix[i] {
+				if cost <= test.maxCost && cost != routingkit.MaxDistance {
+					expected = append(expected, routingkit.NearbyFacility{Index: j, Cost: cost})
+				}
+			}
+			sort.SliceStable(expected, func(a, b int) bool {
+				return expected[a].Cost < expected[b].Cost
+			})
+			if len(expected) > test.k {
+				expected = expected[:test.k]
+			}
+
+			facilities, err := index.NearestFacilities(ctx, p, test.k, test.maxCost)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(expected, facilities) {
+				t.Errorf("expected %d nearest facilities %v within %d from %v, got %v",
+					test.k, expected, test.maxCost, p, facilities)
+			}
+		}
+	}
+
+	// every facility appears twice, so equal costs are ordered by index even
+	// though the search stops once the k nearest are known
+	twice, err := cli.NewFacilityIndex(ctx, append(points[1:3:3], points[1:3]...))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer twice.Delete()
+	facilities, err := twice.NearestFacilities(ctx, points[0], 3, routingkit.MaxDistance)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []routingkit.NearbyFacility{
+		{Index: 0, Cost: matrix[0][1]},
+		{Index: 2, Cost: matrix[0][1]},
+		{Index: 1, Cost: matrix[0][2]},
+	}
+	if matrix[0][2] < matrix[0][1] {
+		expected = []routingkit.NearbyFacility{
+			{Index: 1, Cost: matrix[0][2]},
+			{Index: 3, Cost: matrix[0][2]},
+			{Index: 0, Cost: matrix[0][1]},
+		}
+	}
+	if !reflect.DeepEqual(expected, facilities) {
+		t.Errorf("expected nearest facilities %v, got %v", expected, facilities)
+	}
+
+	if _, err := index.NearestFacilities(ctx, points[0], -1, 1000); err == nil {
+		t.Errorf("expected error for a negative number of facilities")
+	}
+	if _, err := index.NearestFacilities(ctx, routingkit.Point{Lon: 200}, 1, 1000); err == nil {
+		t.Errorf("expected error for an invalid point")
+	}
+	if _, err := cli.NewFacilityIndex(ctx, []routingkit.Point{{Lat: 100}}); err == nil {
+		t.Errorf("expected error for an invalid facility")
+	}
+}
+
+func TestMapMatch(t *testing.T) {
+	from := routingkit.Point{Lon: -76.587490, Lat: 39.299710}
+	to := routingkit.Point{Lon: -76.620000, Lat: 39.320000}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+	route, err := cli.FindRoute(ctx, from, to)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// a trace along the route with a position about every 100 meters, 10
+	// seconds apart and a few meters off the road, and a position far from
+	// any road
+	start := time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC)
+	var trace []routingkit.TimedPoint
+	for i := 1; i < len(route.Waypoints); i++ {
+		a, b := route.Waypoints[i-1], route.Waypoints[i]
+		steps := int(haversine(a, b)/100) + 1
+		for s := 0; s < steps; s++ {
+			f := float32(s) / float32(steps)
+			offset := float32(0.00005)
+			if len(trace)%2 == 1 {
+				offset = -offset
+			}
+			trace = append(trace, routingkit.TimedPoint{
+				Point: routingkit.Point{Lon: a.Lon + f*(b.Lon-a.Lon), Lat: a.Lat + f*(b.Lat-a.Lat) + offset},
+				Time:  start.Add(time.Duration(len(trace)) * 10 * time.Second),
+			})
+		}
+	}
+	outlier := len(trace) / 2
+	trace[outlier].Point = routingkit.Point{Lon: -76.0, Lat: 39.0}
+
+	result, err := cli.MapMatch(ctx, trace)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Points) != len(trace) {
+		t.Fatalf("expected %d matched points, got %d", len(trace), len(result.Points))
+	}
+	for i, p := range result.Points {
+		if i == outlier {
+			if p.Matched {
+				t.Errorf("expected position %d far from any road to be unmatched, got %+v", i, p)
+			}
+			continue
+		}
+		if !p.Matched || haversine(p.Point, trace[i].Point) > 20 || p.Confidence <= 0 || p.Confidence > 1 {
+			t.Errorf("expected position %d at %v to be matched nearby, got %+v", i, trace[i].Point, p)
+		}
+	}
+	// the trace ends less than 100 meters before the end of the route
+	if math.Abs(float64(result.Distance)-float64(route.Distance)) > 150 {
+		t.Errorf("expected matched distance close to %d, got %d", route.Distance, result.Distance)
+	}
+	if len(result.Path) < 2 || haversine(result.Path[0], from) > 20 {
+		t.Errorf("expected path starting at %v, got %v", from, result.Path)
+	}
+
+	result, err = cli.MapMatch(ctx, nil)
+	if err != nil || len(result.Points) != 0 || len(result.Path) != 0 || result.Distance != 0 {
+		t.Errorf("expected empty match of an empty trace, got %+v and error %v", result, err)
+	}
+	result, err = cli.MapMatch(ctx, trace[:1])
+	if err != nil || !result.Points[0].Matched || len(result.Path) != 1 || result.Distance != 0 {
+		t.Errorf("expected single matched position, got %+v and error %v", result, err)
+	}
+	unordered := []routingkit.TimedPoint{trace[1], trace[0]}
+	if _, err := cli.MapMatch(ctx, unordered); err == nil {
+		t.Errorf("expected error for a trace not ordered by time")
+	}
+	invalid := []routingkit.TimedPoint{{Point: routingkit.Point{Lon: 200}, Time: start}}
+	if _, err := cli.MapMatch(ctx, invalid); err == nil {
+		t.Errorf("expected error for an invalid point")
+	}
+}
+
+func TestHeadedSnapping(t *testing.T) {
+	// a point between the carriageways of a divided road running north and
+	// south, with the northbound one to the east
+	point := routingkit.Point{Lon: -76.594025, Lat: 39.291990}
+	target := routingkit.Point{Lon: -76.587490, Lat: 39.299710}
+	north := routingkit.HeadedPoint{Point: point, Bearing: 0, Tolerance: 30}
+	south := routingkit.HeadedPoint{Point: point, Bearing: 180, Tolerance: 30}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+
+	// without a heading, points are snapped as usual
+	plain, err := cli.FindRoute(ctx, point, target)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	headed, err := cli.FindHeadedRoute(ctx, routingkit.HeadedPoint{Point: point, Bearing: 90}, routingkit.HeadedPoint{Point: target})
+	if err != nil || !reflect.DeepEqual(plain, headed) {
+		t.Errorf("expected route %+v without headings, got %+v and error %v", plain, headed, err)
+	}
+
+	northbound, err := cli.FindHeadedRoute(ctx, north, routingkit.HeadedPoint{Point: target})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	southbound, err := cli.FindHeadedRoute(ctx, south, routingkit.HeadedPoint{Point: target})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !northbound.Reachable || !southbound.Reachable {
+		t.Fatalf("expected reachable routes, got %+v and %+v", northbound, southbound)
+	}
+	if haversine(northbound.Source, point) > 30 || haversine(southbound.Source, point) > 30 ||
+		northbound.Source.Lon <= southbound.Source.Lon || haversine(northbound.Source, southbound.Source) < 10 {
+		t.Errorf("expected sources on the northbound and southbound carriageways near %v, got %v and %v",
+			point, northbound.Source, southbound.Source)
+	}
+	// arriving at the point northbound ends on the northbound carriageway
+	arriving, err := cli.FindHeadedRoute(ctx, routingkit.HeadedPoint{Point: target}, north)
+	if err != nil || arriving.Target != northbound.Source {
+		t.Errorf("expected route to %v, got %+v and error %v", northbound.Source, arriving, err)
+	}
+
+	distances, err := cli.ComputeHeadedDistances(ctx, north, []routingkit.HeadedPoint{{Point: target}, south})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	uturn, err := cli.FindHeadedRoute(ctx, north, south)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(distances, []uint32{northbound.Cost, uturn.Cost}) {
+		t.Errorf("expected distances %v, got %v", []uint32{northbound.Cost, uturn.Cost}, distances)
+	}
+	distances, err = cli.ComputeHeadedDistances(ctx, routingkit.HeadedPoint{Point: point}, []routingkit.HeadedPoint{{Point: target}})
+	if err != nil || !reflect.DeepEqual(distances, []uint32{plain.Cost}) {
+		t.Errorf("expected distances %v without headings, got %v and error %v", []uint32{plain.Cost}, distances, err)
+	}
+
+	// on a curve of Townway, the road runs west at the point although the
+	// segment it lies on runs southwest from end to end
+	geometryCli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car(), routingkit.WithGeometry())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer geometryCli.Delete()
+	curve := routingkit.Point{Lon: -76.60155, Lat: 39.306866}
+	west := routingkit.HeadedPoint{Point: curve, Bearing: 270, Tolerance: 30}
+	route, err := geometryCli.FindHeadedRoute(ctx, west, routingkit.HeadedPoint{Point: target})
+	if err != nil || !route.Reachable || haversine(route.Source, curve) > 1 {
+		t.Errorf("expected route from %v, got %+v and error %v", curve, route, err)
+	}
+
+	invalid := []routingkit.HeadedPoint{
+		{Point: routingkit.Point{Lon: 200}},
+		{Point: point, Bearing: 400, Tolerance: 30},
+		{Point: point, Bearing: 90, Tolerance: -1},
+		{Point: point, Bearing: 90, Tolerance: 200},
+	}
+	for _, p := range invalid {
+		if _, err := cli.FindHeadedRoute(ctx, p, north); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+		if _, err := cli.ComputeHeadedDistances(ctx, north, []routingkit.HeadedPoint{p}); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+	}
+}
+
+func TestOneToAll(t *testing.T) {
+	source := routingkit.Point{Lon: -76.587490, Lat: 39.299710}
+	points := []routingkit.Point{
+		{Lon: -76.594045, Lat: 39.300524},
+		{Lon: -76.582855, Lat: 39.309095},
+		{Lon: -76.599388, Lat: 39.302014},
+		{Lon: -76.0, Lat: 39.0},
+	}
+
+	cli, err := routingkit.NewTravelTimeClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+
+	m, snapped, err := cli.OneToAll(ctx, source)
+	if err != nil || !snapped {
+		t.Fatalf("expected snapped source, got %v and error %v", snapped, err)
+	}
+	nodes := cli.Nodes()
+	if len(m.Costs) != len(nodes) || m.Source != source {
+		t.Fatalf("expected %d costs from %v, got %d from %v", len(nodes), source, len(m.Costs), m.Source)
+	}
+	nearest, _, err := cli.FindNearest(ctx, source)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for i, cost := range m.Costs {
+		if (cost == 0) != (nodes[i] == nearest) {
+			t.Errorf("expected cost 0 exactly at %v, got %d at %v", nearest, cost, nodes[i])
+		}
+	}
+
+	sampled, err := cli.SampleCosts(ctx, m, points)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	targets := make([][]float32, len(points))
+	for i, p := range points {
+		targets[i] = []float32{p.Lon, p.Lat}
+	}
+	expected := cli.TravelTimes([]float32{source.Lon, source.Lat}, targets)
+	if !reflect.DeepEqual(expected, sampled) {
+		t.Errorf("expected sampled costs %v, got %v", expected, sampled)
+	}
+
+	// the search state of the query slot is reused, so a search from another
+	// source must not leave costs behind
+	if _, _, err := cli.OneToAll(ctx, points[0]); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	again, _, err := cli.OneToAll(ctx, source)
+	if err != nil || !reflect.DeepEqual(m, again) {
+		t.Errorf("expected the same costs from %v again, got error %v", source, err)
+	}
+
+	m, snapped, err = cli.OneToAll(ctx, points[3])
+	if err != nil || snapped {
+		t.Fatalf("expected source that cannot be snapped, got %v and error %v", snapped, err)
+	}
+	for _, cost := range m.Costs {
+		if cost != routingkit.MaxDistance {
+			t.Fatalf("expected only unreachable nodes, got cost %d", cost)
+		}
+	}
+
+	if _, err := cli.SampleCosts(ctx, routingkit.CostMap{Source: source, Costs: m.Costs[1:]}, points); err == nil {
+		t.Errorf("expected error for a cost map of another network")
+	}
+	if _, _, err := cli.OneToAll(ctx, routingkit.Point{Lon: 200}); err == nil {
+		t.Errorf("expected error for an invalid point")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := cli.OneToAll(canceled, source); err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestIsochrone(t *testing.T) {
+	center := routingkit.Point{Lon: -76.587490, Lat: 39.299710}
+	points := []routingkit.Point{
+		{Lon: -76.594045, Lat: 39.300524},
+		{Lon: -76.582855, Lat: 39.309095},
+		{Lon: -76.599388, Lat: 39.302014},
+	}
+	limits := []uint32{60000, 180000}
+
+	cli, err := routingkit.NewTravelTimeClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+
+	features, snapped, err := cli.Isochrone(ctx, center, limits)
+	if err != nil || !snapped {
+		t.Fatalf("expected snapped center, got %v and error %v", snapped, err)
+	}
+	if len(features) != len(limits) {
+		t.Fatalf("expected %d features, got %d", len(limits), len(features))
+	}
+	for i, f := range features {
+		if f.Type != "Feature" || f.Geometry.Type != "MultiPolygon" || f.Properties["limit"] != limits[i] {
+			t.Errorf("expected MultiPolygon feature with limit %d, got %+v", limits[i], f)
+		}
+		if len(f.Geometry.Coordinates) == 0 {
+			t.Errorf("expected area within %d, got none", limits[i])
+		}
+	}
+
+	// the roads reachable within a limit are covered by its area
+	nearest, _, err := cli.FindNearest(ctx, center)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !inMultiPolygon(features[0].Geometry.Coordinates, nearest) {
+		t.Errorf("expected %v to be within %v", nearest, features[0].Geometry.Coordinates)
+	}
+	targets := make([][]float32, len(points))
+	for i, p := range points {
+		targets[i] = []float32{p.Lon, p.Lat}
+	}
+	times := cli.TravelTimes([]float32{center.Lon, center.Lat}, targets)
+	for i, p := range points {
+		nearest, _, err := cli.FindNearest(ctx, p)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for l, limit := range limits {
+			if times[i] <= limit && !inMultiPolygon(features[l].Geometry.Coordinates, nearest) {
+				t.Errorf("expected %v reached in %d to be within the area of %d", nearest, times[i], limit)
+			}
+		}
+	}
+
+	// the roads from which the center is reachable within a limit are covered
+	// by its inbound area
+	inbound, snapped, err := cli.InboundIsochrone(ctx, center, limits)
+	if err != nil || !snapped || len(inbound) != len(limits) {
+		t.Fatalf("expected %d inbound features, got %v, %v and error %v", len(limits), inbound, snapped, err)
+	}
+	for i, p := range points {
+		nearest, _, err := cli.FindNearest(ctx, p)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		time := cli.TravelTimes([]float32{p.Lon, p.Lat}, [][]float32{{center.Lon, center.Lat}})[0]
+		for l, limit := range limits {
+			if time <= limit && !inMultiPolygon(inbound[l].Geometry.Coordinates, nearest) {
+				t.Errorf("expected %v reaching the center in %d to be within the inbound area of %d", points[i], time, limit)
+			}
+		}
+	}
+
+	// the search state of the query slot is reused, so the inbound search
+	// must not change the areas of the next search
+	again, _, err := cli.Isochrone(ctx, center, limits)
+	if err != nil || !reflect.DeepEqual(features, again) {
+		t.Errorf("expected the same areas around %v again, got error %v", center, err)
+	}
+
+	b, err := json.Marshal(routingkit.NewFeatureCollection(features))
+	if err != nil {
+		t.Fatalf("encoding features: %v", err)
+	}
+	var collection struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+	if err := json.Unmarshal(b, &collection); err != nil || collection.Type != "FeatureCollection" || len(collection.Features) != len(limits) {
+		t.Errorf("expected feature collection of %d features, got %s", len(limits), b)
+	}
+
+	features, snapped, err = cli.Isochrone(ctx, routingkit.Point{Lon: -76.0, Lat: 39.0}, limits)
+	if err != nil || snapped || len(features) != len(limits) || len(features[0].Geometry.Coordinates) != 0 {
+		t.Errorf("expected empty areas around a center that cannot be snapped, got %v, %v and error %v", features, snapped, err)
+	}
+	if _, _, err := cli.Isochrone(ctx, center, nil); err == nil {
+		t.Errorf("expected error without limits")
+	}
+	if _, _, err := cli.Isochrone(ctx, routingkit.Point{Lon: 200}, limits); err == nil {
+		t.Errorf("expected error for an invalid point")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := cli.InboundIsochrone(canceled, center, limits); err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+// inMultiPolygon tells whether the point lies within the GeoJSON MultiPolygon
+// coordinates.
+func inMultiPolygon(coordinates [][][][]float64, p routingkit.Point) bool {
+	x, y := float64(p.Lon), float64(p.Lat)
+	for _, polygon := range coordinates {
+		inside := false
+		for _, ring := range polygon {
+			for i := 1; i < len(ring); i++ {
+				a, b := ring[i-1], ring[i]
+				if (a[1] > y) != (b[1] > y) && x < a[0]+(y-a[1])*(b[0]-a[0])/(b[1]-a[1]) {
+					inside = !inside
+				}
+			}
+		}
+		if inside {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFindRoute(t *testing.T) {
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+
+	from := routingkit.LatLng{Lat: 39.299710, Lng: -76.587490}.Point()
+	to := routingkit.Point{Lon: -76.582855, Lat: 39.309095}
+	result, err := cli.FindRoute(ctx, from, to)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !result.Reachable || result.Cost != 1496 || result.Distance != 1496 {
+		t.Errorf("expected reachable route of 1496 m, got %+v", result)
+	}
+	if len(result.Waypoints) == 0 ||
+		result.Waypoints[0] != result.Source ||
+		result.Waypoints[len(result.Waypoints)-1] != result.Target {
+		t.Errorf("expected waypoints from %v to %v, got %v", result.Source, result.Target, result.Waypoints)
+	}
+	distance, waypoints := cli.Route([]float32{from.Lon, from.Lat}, []float32{to.Lon, to.Lat})
+	if distance != result.Cost || len(waypoints) != len(result.Waypoints) {
+		t.Errorf("expected Route to match FindRoute, got %d and %v", distance, waypoints)
+	}
+
+	result, err = cli.FindRoute(ctx, from, routingkit.Point{Lon: -76.0, Lat: 39.0})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Reachable || result.Cost != routingkit.MaxDistance || result.Target != (routingkit.Point{}) {
+		t.Errorf("expected unreachable route to unsnapped target, got %+v", result)
+	}
+
+	matrix, err := cli.ComputeMatrix(ctx, []routingkit.Point{from}, []routingkit.Point{to})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual([][]uint32{{1496}}, matrix) {
+		t.Errorf("expected matrix %v, got %v", [][]uint32{{1496}}, matrix)
+	}
+
+	invalid := []routingkit.Point{
+		{Lon: -76.587490, Lat: 91},
+		{Lon: 181, Lat: 39.299710},
+		{Lon: float32(math.NaN()), Lat: 39.299710},
+	}
+	for i, p := range invalid {
+		if _, err := cli.FindRoute(ctx, p, to); err == nil {
+			t.Errorf("[%d] expected error for %v", i, p)
+		}
+		if _, _, err := cli.FindNearest(ctx, p); err == nil {
+			t.Errorf("[%d] expected error for %v", i, p)
+		}
+		if _, err := cli.ComputeMatrix(ctx, []routingkit.Point{from}, []routingkit.Point{p}); err == nil {
+			t.Errorf("[%d] expected error for %v", i, p)
+		}
+	}
+
+	if _, _, err := cli.RouteContext(ctx, []float32{-76.587490}, []float32{to.Lon, to.Lat}); err == nil {
+		t.Errorf("expected error for short point")
+	}
+	if distance := cli.Distance([]float32{-76.587490}, []float32{to.Lon, to.Lat}); distance != routingkit.MaxDistance {
+		t.Errorf("expected %d for short point, got %d", routingkit.MaxDistance, distance)
+	}
+	if _, err := cli.MatrixContext(ctx, [][]float32{{39.299710, -91}}, [][]float32{{to.Lon, to.Lat}}); err == nil {
+		t.Errorf("expected error for invalid point")
+	}
+}
+
+func TestQueryStatus(t *testing.T) {
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	ctx := context.Background()
+
+	snapped := routingkit.Point{Lon: -76.587490, Lat: 39.299710}
+	notSnapped := routingkit.Point{Lon: -76.0, Lat: 39.0}
+	target := routingkit.Point{Lon: -76.582855, Lat: 39.309095}
+	// disconnectedTo cannot be reached from the other points
+	disconnectedFrom := routingkit.Point{Lon: -76.60586, Lat: 39.30228}
+	disconnectedTo := routingkit.Point{Lon: -76.60548, Lat: 39.30772}
+
+	tests := []struct {
+		from, to routingkit.Point
+		expected routingkit.Status
+	}{
+		{snapped, target, routingkit.StatusOK},
+		{notSnapped, target, routingkit.StatusSourceNotSnapped},
+		{notSnapped, notSnapped, routingkit.StatusSourceNotSnapped},
+		{snapped, notSnapped, routingkit.StatusTargetNotSnapped},
+		{disconnectedFrom, disconnectedTo, routingkit.StatusNoPath},
+	}
+	for i, test := range tests {
+		result, err := cli.FindRoute(ctx, test.from, test.to)
+		if err != nil {
+			t.Fatalf("[%d] expected no error, got %v", i, err)
+		}
+		if result.Status != test.expected {
+			t.Errorf("[%d] expected status %v, got %v", i, test.expected, result.Status)
+		}
+		if result.Reachable != (test.expected == routingkit.StatusOK) {
+			t.Errorf("[%d] expected reachable to be %v", i, !result.Reachable)
+		}
+	}
+
+	matrix, statuses, err := cli.ComputeMatrixStatus(
+		ctx,
+		[]routingkit.Point{snapped, notSnapped, disconnectedFrom},
+		[]routingkit.Point{target, notSnapped, disconnectedTo},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	max := routingkit.MaxDistance
+	expectedMatrix := [][]uint32{{1496, max, max}, {max, max, max}, {2417, max, max}}
+	expectedStatuses := [][]routingkit.Status{
+		{routingkit.StatusOK, routingkit.StatusTargetNotSnapped, routingkit.StatusNoPath},
+		{routingkit.StatusSourceNotSnapped, routingkit.StatusSourceNotSnapped, routingkit.StatusSourceNotSnapped},
+		{routingkit.StatusOK, routingkit.StatusTargetNotSnapped, routingkit.StatusNoPath},
+	}
+	if !reflect.DeepEqual(expectedMatrix, matrix) {
+		t.Errorf("expected matrix %v, got %v", expectedMatrix, matrix)
+	}
+	if !reflect.DeepEqual(expectedStatuses, statuses) {
+		t.Errorf("expected statuses %v, got %v", expectedStatuses, statuses)
+	}
+}
+
+func TestSnap(t *testing.T) {
+	tests := []struct {
+		point    routingkit.Point
+		snapped  bool
+		expected routingkit.SnapResult
+	}{
+		{
+			point:   routingkit.Point{Lon: -76.587490, Lat: 39.299710},
+			snapped: true,
+			expected: routingkit.SnapResult{
+				Point:   routingkit.Point{Lon: -76.58753, Lat: 39.29971},
+				WayID:   72832596,
+				Name:    "East Madison Street",
+				Highway: "primary",
+			},
+		},
+		{
+			point:   routingkit.Point{Lon: -76.582855, Lat: 39.309095},
+			snapped: true,
+			expected: routingkit.SnapResult{
+				Point:   routingkit.Point{Lon: -76.5829, Lat: 39.30897},
+				WayID:   548254156,
+				Name:    "North Milton Avenue",
+				Highway: "tertiary",
+			},
+		},
+		{
+			point: routingkit.Point{Lon: -76.0, Lat: 39.0},
+		},
+	}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+
+	for i, test := range tests {
+		got, ok, err := cli.Snap(context.Background(), test.point)
+		if err != nil {
+			t.Fatalf("[%d] expected no error, got %v", i, err)
+		}
+		if ok != test.snapped {
+			t.Errorf("[%d] expected snapped to be %v", i, test.snapped)
+		}
+		if !ok {
+			continue
+		}
+		if d := float64(got.Distance); math.Abs(d-haversine(test.point, got.Point)) > 1 {
+			t.Errorf("[%d] expected distance to snapped point, got %v m", i, d)
+		}
+		got.Distance = 0
+		if got != test.expected {
+			t.Errorf("[%d] expected %+v, got %+v", i, test.expected, got)
+		}
+	}
+}
+
+func TestArcSnapping(t *testing.T) {
+	points := []routingkit.Point{
+		{Lon: -76.587490, Lat: 39.299710},
+		{Lon: -76.582855, Lat: 39.309095},
+		// on the same segment of East Madison Street as the first point
+		{Lon: -76.58742, Lat: 39.29972},
+	}
+	expected := [][]uint32{
+		{0, 1513, 189},
+		{1440, 0, 1434},
+		{6, 1519, 0},
+	}
+
+	nodeCli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer nodeCli.Delete()
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car(), routingkit.WithArcSnapping())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+
+	ctx := context.Background()
+	for i, p := range points {
+		nodeSnap, _, err := nodeCli.Snap(ctx, p)
+		if err != nil {
+			t.Fatalf("[%d] expected no error, got %v", i, err)
+		}
+		arcSnap, ok, err := cli.Snap(ctx, p)
+		if err != nil || !ok {
+			t.Fatalf("[%d] expected point to be snapped, got %v", i, err)
+		}
+		if arcSnap.Distance > nodeSnap.Distance {
+			t.Errorf("[%d] expected arc to be at most %v m away, got %v m", i, nodeSnap.Distance, arcSnap.Distance)
+		}
+	}
+
+	matrix, err := cli.ComputeMatrix(ctx, points, points)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if diff := cmp.Diff(expected, matrix); diff != "" {
+		t.Errorf("unexpected matrix (-want +got):\n%s", diff)
+	}
+
+	for i, from := range points {
+		for j, to := range points {
+			route, err := cli.FindRoute(ctx, from, to)
+			if err != nil {
+				t.Fatalf("[%d,%d] expected no error, got %v", i, j, err)
+			}
+			if route.Cost != expected[i][j] {
+				t.Errorf("[%d,%d] expected cost %v, got %v", i, j, expected[i][j], route.Cost)
+			}
+			n := len(route.Waypoints)
+			if n < 2 || route.Waypoints[0] != route.Source || route.Waypoints[n-1] != route.Target {
+				t.Errorf("[%d,%d] expected route from %v to %v, got %v", i, j, route.Source, route.Target, route.Waypoints)
+			}
+		}
+	}
+
+	// the route stays on the segment between the projected points
+	route, err := cli.FindRoute(ctx, points[2], points[0])
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(route.Waypoints) != 2 || math.Abs(float64(route.Cost)-haversine(route.Source, route.Target)) > 1 {
+		t.Errorf("expected direct route, got %+v", route)
+	}
+
+	// points on curved roads are projected onto the shape of the road, and
+	// routes through them cost as much as the route past them
+	from, to := points[1], routingkit.Point{Lon: -76.60586, Lat: 39.30228}
+	route, err = cli.FindRoute(ctx, from, to)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	curves := []routingkit.Point{
+		{Lon: -76.60114, Lat: 39.306923},
+		{Lon: -76.60196, Lat: 39.306778},
+		{Lon: -76.60195, Lat: 39.305405},
+		{Lon: -76.60251, Lat: 39.30311},
+	}
+	for i, p := range curves {
+		snapped, _, err := cli.Snap(ctx, p)
+		if err != nil || snapped.Distance > 0.5 {
+			t.Errorf("[%d] expected %v to lie on the road, got %+v (%v)", i, p, snapped, err)
+		}
+		first, err := cli.FindRoute(ctx, from, p)
+		if err != nil {
+			t.Fatalf("[%d] expected no error, got %v", i, err)
+		}
+		rest, err := cli.FindRoute(ctx, p, to)
+		if err != nil {
+			t.Fatalf("[%d] expected no error, got %v", i, err)
+		}
+		// the lengths of the roads are rounded to meters
+		if cost := first.Cost + rest.Cost; math.Abs(float64(cost)-float64(route.Cost)) > 2 {
+			t.Errorf("[%d] expected cost of %v m through the point, got %v", i, route.Cost, cost)
+		}
+	}
+}
+
+func TestGeometry(t *testing.T) {
+	from := routingkit.Point{Lon: -76.582855, Lat: 39.309095}
+	to := routingkit.Point{Lon: -76.60586, Lat: 39.30228}
+
+	cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+	geometryCli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car(), routingkit.WithGeometry())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer geometryCli.Delete()
+
+	ctx := context.Background()
+	route, err := cli.FindRoute(ctx, from, to)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	detailed, err := geometryCli.FindRoute(ctx, from, to)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if detailed.Cost != route.Cost {
+		t.Errorf("expected cost %v, got %v", route.Cost, detailed.Cost)
+	}
+	if len(detailed.Waypoints) <= len(route.Waypoints) {
+		t.Fatalf("expected more than %v waypoints, got %v", len(route.Waypoints), len(detailed.Waypoints))
+	}
+
+	// the intersections are kept and the waypoints follow the road
+	j := 0
+	length := 0.0
+	for i, p := range detailed.Waypoints {
+		if j < len(route.Waypoints) && p == route.Waypoints[j] {
+			j++
+		}
+		if i > 0 {
+			length += haversine(detailed.Waypoints[i-1], p)
+		}
+	}
+	if j != len(route.Waypoints) {
+		t.Errorf("expected waypoints to contain %v, got %v", route.Waypoints, detailed.Waypoints)
+	}
+	if math.Abs(length-float64(detailed.Distance)) > 0.02*float64(detailed.Distance) {
+		t.Errorf("expected waypoints to be about %v m long, got %.0f m", detailed.Distance, length)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	from := routingkit.Point{Lon: -76.587490, Lat: 39.299710}
+	to := routingkit.Point{Lon: -76.582855, Lat: 39.309095}
+	last := routingkit.Segment{
+		WayID:    499204905,
+		Name:     "East Federal Street",
+		Highway:  "tertiary",
+		Distance: 36,
+		Duration: 3160,
+		Speed:    41,
+	}
+
+	for _, opts := range [][]routingkit.ClientOption{nil, {routingkit.WithArcSnapping()}} {
+		cli, err := routingkit.NewTravelTimeClient(marylandMap, routingkit.Car(), opts...)
+		if err != nil {
+			t.Fatalf("creating Client: %v", err)
+		}
+		route, err := cli.FindRoute(context.Background(), from, to)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var distance, duration uint32
+		found := false
+		for i, s := range route.Segments {
+			distance += s.Distance
+			duration += s.Duration
+			// the segment starts in the direction of the waypoint after its
+			// start, which follows the shape of the road with arc snapping;
+			// the coordinates are too coarse to tell the direction to close
+			// waypoints
+			for k := 0; k+1 < len(route.Waypoints); k++ {
+				if route.Waypoints[k] != s.From || haversine(s.From, route.Waypoints[k+1]) < 20 {
+					continue
+				}
+				b := bearing(route.Waypoints[k], route.Waypoints[k+1])
+				if d := math.Abs(math.Mod(b-s.StartBearing+540, 360) - 180); d > 1 {
+					t.Errorf("[%d] expected start bearing %.0f, got %.0f", i, b, s.StartBearing)
+				}
+				break
+			}
+			s.From, s.To, s.Branches = routingkit.Point{}, routingkit.Point{}, 0
+			s.StartBearing, s.EndBearing = 0, 0
+			found = found || s == last
+		}
+		if distance != route.Distance || duration != route.TravelTime {
+			t.Errorf("expected segments to sum up to %v m and %v ms, got %v m and %v ms",
+				route.Distance, route.TravelTime, distance, duration)
+		}
+		if !found {
+			t.Errorf("expected segments to contain %+v, got %+v", last, route.Segments)
+		}
+
+		directions := route.Directions()
+		n := len(directions)
+		if n < 3 || directions[0].Type != routingkit.ManeuverDepart || directions[n-1].Type != routingkit.ManeuverArrive {
+			t.Fatalf("expected directions from departure to arrival, got %+v", directions)
+		}
+		distance = 0
+		for _, m := range directions {
+			distance += m.Distance
+		}
+		if distance != route.Distance {
+			t.Errorf("expected maneuvers to cover %v m, got %v m", route.Distance, distance)
 		}
+		cli.Delete()
 	}
 }
 
-func TestDistances(t *testing.T) {
-	tests := []struct {
-		source       []float32
-		destinations [][]float32
-		snap         float32
-		profile      routingkit.Profile
-		ch           string
-
-		expected []uint32
-	}{
-		{
-			source: []float32{-76.587490, 39.299710},
-			destinations: [][]float32{
-				{-76.582855, 39.309095},
-				{-76.591286, 39.298443},
-			},
-			snap:    1000,
-			profile: routingkit.Car(),
+func TestRouteVia(t *testing.T) {
+	points := []routingkit.Point{
+		{Lon: -76.587490, Lat: 39.299710},
+		{Lon: -76.582855, Lat: 39.309095},
+		{Lon: -76.60586, Lat: 39.30228},
+	}
 
-			expected: []uint32{1496, 508},
-		},
-		{
-			source: []float32{-76.587490, 39.299710},
-			destinations: [][]float32{
-				{-76.582855, 39.309095},
-				{-76.591286, 39.298443},
-			},
-			snap:    1000,
-			profile: routingkit.Bike(),
+	for _, opts := range [][]routingkit.ClientOption{nil, {routingkit.WithArcSnapping()}} {
+		cli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car(), opts...)
+		if err != nil {
+			t.Fatalf("creating Client: %v", err)
+		}
+		ctx := context.Background()
 
-			expected: []uint32{1440, 617},
-		},
-		{
-			source: []float32{-76.587490, 39.299710},
-			destinations: [][]float32{
-				{-76.582855, 39.309095},
-				{-76.591286, 39.298443},
-			},
-			snap:    1000,
-			profile: routingkit.Pedestrian(),
+		stops := make([]routingkit.Stop, len(points))
+		var cost uint32
+		var legs []uint32
+		for i, p := range points {
+			stops[i] = routingkit.Stop{Point: p}
+			if i > 0 {
+				leg, err := cli.FindRoute(ctx, points[i-1], p)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				cost += leg.Cost
+				legs = append(legs, leg.Cost)
+			}
+		}
+		route, err := cli.FindRouteVia(ctx, stops)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !route.Reachable || route.Cost != cost || len(route.Legs) != len(legs) {
+			t.Errorf("expected cost %v over %v legs, got %+v", cost, len(legs), route)
+		}
+		n := len(route.Waypoints)
+		if n < 2 || route.Waypoints[0] != route.Legs[0].Source || route.Waypoints[n-1] != route.Legs[1].Target {
+			t.Errorf("expected waypoints from the first to the last stop, got %v", route.Waypoints)
+		}
 
-			expected: []uint32{1429, 428},
-		},
-		{
-			// should receive MaxDistance for invalid destinations
-			source: []float32{-76.587490, 39.299710},
-			destinations: [][]float32{
-				{-76.60548, 39.30772},
-				{-76.582855, 39.309095},
-				{-76.584897, 39.280774},
-				{-76.599388, 39.302014},
-			},
-			snap:    100,
-			profile: routingkit.Car(),
+		total, costs, waypoints := cli.RouteVia([][]float32{
+			{points[0].Lon, points[0].Lat},
+			{points[1].Lon, points[1].Lat},
+			{points[2].Lon, points[2].Lat},
+		})
+		if total != cost || len(waypoints) != n {
+			t.Errorf("expected cost %v and %v waypoints, got %v and %v", cost, n, total, len(waypoints))
+		}
+		if diff := cmp.Diff(legs, costs); diff != "" {
+			t.Errorf("unexpected leg costs (-want +got):\n%s", diff)
+		}
 
-			expected: []uint32{routingkit.MaxDistance, 1496, routingkit.MaxDistance, 1259},
-		},
-		{
-			// invalid source - should receive all MaxDistance
-			source: []float32{-76.60586, 39.30228},
-			destinations: [][]float32{
-				{-76.60548, 39.30772},
-				{-76.584897, 39.280774},
-			},
-			snap:    10,
-			profile: routingkit.Car(),
+		// passing through without a U-turn is never shorter than stopping
+		stops[1].PassThrough = true
+		through, err := cli.FindRouteVia(ctx, stops)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !through.Reachable || through.Cost < route.Cost || through.Legs[0].Cost != route.Legs[0].Cost {
+			t.Errorf("expected pass-through route to cost at least %v, got %+v", route.Cost, through)
+		}
 
-			expected: []uint32{routingkit.MaxDistance, routingkit.MaxDistance},
-		},
+		if _, err := cli.FindRouteVia(ctx, stops[:1]); err == nil {
+			t.Errorf("expected error for a single stop")
+		}
+		cli.Delete()
 	}
+}
 
-	for i, test := range tests {
-		cli, err := routingkit.NewDistanceClient(marylandMap, test.profile)
+func TestAlternatives(t *testing.T) {
+	from := routingkit.Point{Lon: -76.582855, Lat: 39.309095}
+	to := routingkit.Point{Lon: -76.60586, Lat: 39.30228}
+
+	for _, opts := range [][]routingkit.ClientOption{nil, {routingkit.WithArcSnapping()}} {
+		cli, err := routingkit.NewTravelTimeClient(marylandMap, routingkit.Car(), opts...)
 		if err != nil {
 			t.Fatalf("creating Client: %v", err)
 		}
-		cli.SetSnapRadius(test.snap)
-		got := cli.Distances(test.source, test.destinations)
-		if !reflect.DeepEqual(test.expected, got) {
-			t.Errorf("[%d] expected %v, got %v", i, test.expected, got)
+		ctx := context.Background()
+		shortest, err := cli.FindRoute(ctx, from, to)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		routes, err := cli.FindAlternatives(ctx, from, to, 3)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(routes) != 3 || routes[0].Cost != shortest.Cost {
+			t.Fatalf("expected 3 routes starting with one costing %v, got %+v", shortest.Cost, routes)
+		}
+
+		// segments driven on by a previous route
+		driven := map[[2]routingkit.Point]bool{}
+		for i, r := range routes {
+			n := len(r.Waypoints)
+			if !r.Reachable || n < 2 || r.Waypoints[0] != shortest.Waypoints[0] || r.Waypoints[n-1] != shortest.Waypoints[len(shortest.Waypoints)-1] {
+				t.Errorf("[%d] expected route from %v to %v, got %+v", i, from, to, r)
+			}
+			if i > 0 && (r.Cost < routes[i-1].Cost || float64(r.Cost) > 1.25*float64(shortest.Cost)) {
+				t.Errorf("[%d] expected cost between %v and %v, got %v", i, routes[i-1].Cost, 1.25*float64(shortest.Cost), r.Cost)
+			}
+			var overlap uint32
+			for _, s := range r.Segments {
+				if driven[[2]routingkit.Point{s.From, s.To}] {
+					overlap += s.Distance
+				}
+			}
+			// the partial segments at the ends are not limited
+			if i > 0 && overlap > r.Distance/2+100 {
+				t.Errorf("[%d] expected at most half of %v m to overlap, got %v m", i, r.Distance, overlap)
+			}
+			for _, s := range r.Segments {
+				driven[[2]routingkit.Point{s.From, s.To}] = true
+			}
+		}
+
+		costs, waypoints := cli.Alternatives(
+			[]float32{from.Lon, from.Lat}, []float32{to.Lon, to.Lat}, 1,
+		)
+		if len(costs) != 1 || costs[0] != shortest.Cost || len(waypoints) != 1 {
+			t.Errorf("expected a single route costing %v, got %v", shortest.Cost, costs)
 		}
+		if _, err := cli.FindAlternatives(ctx, from, to, 0); err == nil {
+			t.Errorf("expected error for no routes")
+		}
+		cli.Delete()
 	}
 }
 
-func TestMatrix(t *testing.T) {
-	tests := []struct {
-		sources      [][]float32
-		destinations [][]float32
-		profile      routingkit.Profile
-		ch           string
-
-		expected [][]uint32
-	}{
-		{
-			sources: [][]float32{
-				{-76.587490, 39.299710},
-				{-76.594045, 39.300524},
-				{-76.586664, 39.290938},
-				{-76.598423, 39.289484},
-			},
-			destinations: [][]float32{
-				{-76.582855, 39.309095},
-				{-76.599388, 39.302014},
-			},
-			expected: [][]uint32{
-				{1496, 1259},
-				{1831, 575},
-				{2372, 2224},
-				{3399, 1548},
-			},
-			profile: routingkit.Car(),
-		},
-		{
-			sources: [][]float32{
-				{-76.587490, 39.299710},
-				{-76.594045, 39.300524},
-				{-76.586664, 39.290938},
-				{-76.598423, 39.289484},
-			},
-			destinations: [][]float32{
-				{-76.582855, 39.309095},
-				{-76.599388, 39.302014},
-			},
-			expected: [][]uint32{
-				{1440, 1259},
-				{1796, 575},
-				{2372, 2216},
-				{3364, 1548},
-			},
-			profile: routingkit.Bike(),
-		},
-		{
-			sources: [][]float32{
-				{-76.587490, 39.299710},
-				{-76.594045, 39.300524},
-				{-76.586664, 39.290938},
-				{-76.598423, 39.289484},
-			},
-			destinations: [][]float32{
-				{-76.582855, 39.309095},
-				{-76.599388, 39.302014},
-			},
-			expected: [][]uint32{
-				{1429, 1242},
-				{1589, 558},
-				{2367, 2189},
-				{3151, 1533},
-			},
-			profile: routingkit.Pedestrian(),
-		},
+func TestManyToMany(t *testing.T) {
+	points := []routingkit.Point{
+		{Lon: -76.587490, Lat: 39.299710},
+		{Lon: -76.582855, Lat: 39.309095},
+		{Lon: -76.60586, Lat: 39.30228},
+		{Lon: -76.594045, Lat: 39.300524},
+		{Lon: -76.599388, Lat: 39.302014},
+		{Lon: -76.58742, Lat: 39.29972},
+		// not snappable
+		{Lon: -76.0, Lat: 39.0},
 	}
 
-	for i, test := range tests {
-		cli, err := routingkit.NewDistanceClient(marylandMap, test.profile)
+	for _, opts := range [][]routingkit.ClientOption{nil, {routingkit.WithArcSnapping()}} {
+		cli, err := routingkit.NewTravelTimeClient(marylandMap, routingkit.Car(), opts...)
 		if err != nil {
 			t.Fatalf("creating Client: %v", err)
 		}
-		got := cli.Matrix(test.sources, test.destinations)
-		if !reflect.DeepEqual(test.expected, got) {
-			t.Errorf("[%d] expected %v, got %v", i, test.expected, got)
+		ctx := context.Background()
+		matrix, statuses, err := cli.ComputeMatrixStatus(ctx, points, points)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for i, source := range points {
+			// the one-to-many search agrees with the many-to-many search
+			row, err := cli.ComputeTravelTimes(ctx, source, points)
+			if err != nil {
+				t.Fatalf("[%d] expected no error, got %v", i, err)
+			}
+			if diff := cmp.Diff(row, matrix[i]); diff != "" {
+				t.Errorf("[%d] unexpected row (-want +got):\n%s", i, diff)
+			}
+			for j := range points {
+				expected := routingkit.StatusOK
+				switch {
+				case i == len(points)-1:
+					expected = routingkit.StatusSourceNotSnapped
+				case j == len(points)-1:
+					expected = routingkit.StatusTargetNotSnapped
+				}
+				if statuses[i][j] != expected {
+					t.Errorf("[%d,%d] expected status %v, got %v", i, j, expected, statuses[i][j])
+				}
+			}
+		}
+
+		if matrix, err := cli.ComputeMatrix(ctx, points, nil); err != nil || len(matrix) != len(points) || len(matrix[0]) != 0 {
+			t.Errorf("expected %v empty rows, got %v (%v)", len(points), matrix, err)
 		}
+		cli.Delete()
 	}
 }
 
+// bearing returns the initial bearing in degrees from a to b.
+func bearing(a, b routingkit.Point) float64 {
+	rad := func(deg float32) float64 { return float64(deg) * math.Pi / 180 }
+	dLon := rad(b.Lon - a.Lon)
+	y := math.Sin(dLon) * math.Cos(rad(b.Lat))
+	x := math.Cos(rad(a.Lat))*math.Sin(rad(b.Lat)) - math.Sin(rad(a.Lat))*math.Cos(rad(b.Lat))*math.Cos(dLon)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// haversine returns the distance in meters between the points.
+func haversine(a, b routingkit.Point) float64 {
+	rad := func(deg float32) float64 { return float64(deg) * math.Pi / 180 }
+	dLat := rad(b.Lat - a.Lat)
+	dLon := rad(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(a.Lat))*math.Cos(rad(b.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * 6371000 * math.Asin(math.Sqrt(h))
+}
+
 var update *bool
 var cleanCHFiles *bool
 
@@ -790,6 +2358,173 @@ func TestTravelTimeMatrix(t *testing.T) {
 	}
 }
 
+func TestCombinedClient(t *testing.T) {
+	sources := [][]float32{
+		{-76.587490, 39.299710},
+		{-76.594045, 39.300524},
+		{-76.586664, 39.290938},
+		{-76.598423, 39.289484},
+	}
+	destinations := [][]float32{
+		{-76.582855, 39.309095},
+		{-76.599388, 39.302014},
+	}
+
+	cli, err := routingkit.NewCombinedClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer cli.Delete()
+
+	distances, travelTimes := cli.MatrixBoth(sources, destinations)
+	expectedDistances := [][]uint32{
+		{1499, 1268},
+		{1893, 577},
+		{2384, 2250},
+		{3595, 1553},
+	}
+	expectedTravelTimes := [][]uint32{
+		{131599, 110399},
+		{153488, 52230},
+		{205841, 187148},
+		{289818, 127151},
+	}
+	if !reflect.DeepEqual(expectedDistances, distances) {
+		t.Errorf("expected distances %v, got %v", expectedDistances, distances)
+	}
+	if !reflect.DeepEqual(expectedTravelTimes, travelTimes) {
+		t.Errorf("expected travel times %v, got %v", expectedTravelTimes, travelTimes)
+	}
+
+	for i, source := range sources {
+		for j, destination := range destinations {
+			distance, travelTime, waypoints := cli.RouteBoth(source, destination)
+			if distance != distances[i][j] || travelTime != travelTimes[i][j] {
+				t.Errorf(
+					"[%d][%d] expected route of %d m and %d ms, got %d m and %d ms",
+					i, j, distances[i][j], travelTimes[i][j], distance, travelTime,
+				)
+			}
+			if len(waypoints) == 0 {
+				t.Errorf("[%d][%d] expected waypoints", i, j)
+			}
+		}
+	}
+
+	// a headed route is the fastest one of the travel time client
+	ctx := context.Background()
+	from := routingkit.HeadedPoint{Point: routingkit.Point{Lon: -76.594025, Lat: 39.291990}, Bearing: 180, Tolerance: 30}
+	to := routingkit.HeadedPoint{Point: routingkit.Point{Lon: destinations[0][0], Lat: destinations[0][1]}}
+	headed, err := cli.FindHeadedRoute(ctx, from, to)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected, err := cli.TravelTimeClient().FindHeadedRoute(ctx, from, to)
+	if err != nil || !reflect.DeepEqual(expected, headed) {
+		t.Errorf("expected headed route %+v, got %+v and error %v", expected, headed, err)
+	}
+
+	// the shortest routes are at most as long as the fastest ones
+	shortest := cli.DistanceClient().Matrix(sources, destinations)
+	for i := range shortest {
+		for j := range shortest[i] {
+			if shortest[i][j] > distances[i][j] {
+				t.Errorf(
+					"[%d][%d] expected shortest route of at most %d m, got %d m",
+					i, j, distances[i][j], shortest[i][j],
+				)
+			}
+		}
+	}
+
+	// deleting the shared clients leaves the combined client intact
+	distanceCli := cli.DistanceClient()
+	distanceCli.Delete()
+	cli.TravelTimeClient().Delete()
+	if got := cli.TravelTimeClient().Matrix(sources, destinations); !reflect.DeepEqual(expectedTravelTimes, got) {
+		t.Errorf("expected travel times %v after deleting shared clients, got %v", expectedTravelTimes, got)
+	}
+
+	// the deferred Delete after this one has no effect
+	cli.Delete()
+
+	if _, err := routingkit.NewCombinedClient(
+		marylandMap,
+		routingkit.Car(),
+		routingkit.WithCHPath("maryland.ch"),
+	); err == nil {
+		t.Errorf("expected error for contraction hierarchy path")
+	}
+}
+
+func TestPathMetrics(t *testing.T) {
+	sources := [][]float32{
+		{-76.587490, 39.299710},
+		{-76.594045, 39.300524},
+	}
+	destinations := [][]float32{
+		{-76.582855, 39.309095},
+		{-76.599388, 39.302014},
+		// not snappable
+		{-76.0, 39.0},
+	}
+	max := routingkit.MaxDistance
+
+	distanceCli, err := routingkit.NewDistanceClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer distanceCli.Delete()
+	distances, travelTimes := distanceCli.MatrixWithTravelTimes(sources, destinations)
+	expectedDistances := [][]uint32{{1496, 1259, max}, {1831, 575, max}}
+	expectedTravelTimes := [][]uint32{{202266, 129641, max}, {172370, 69591, max}}
+	if !reflect.DeepEqual(expectedDistances, distances) {
+		t.Errorf("expected distances %v, got %v", expectedDistances, distances)
+	}
+	if !reflect.DeepEqual(expectedTravelTimes, travelTimes) {
+		t.Errorf("expected travel times %v, got %v", expectedTravelTimes, travelTimes)
+	}
+	for i, source := range sources {
+		for j, destination := range destinations {
+			// there can be several shortest routes with different travel
+			// times, so only the distance has to match the matrix
+			distance, travelTime, _ := distanceCli.RouteWithTravelTime(source, destination)
+			if distance != distances[i][j] {
+				t.Errorf("[%d][%d] expected route of %d m, got %d m", i, j, distances[i][j], distance)
+			}
+			if distance != max && travelTime == max {
+				t.Errorf("[%d][%d] expected travel time of route", i, j)
+			}
+		}
+	}
+
+	travelTimeCli, err := routingkit.NewTravelTimeClient(marylandMap, routingkit.Car())
+	if err != nil {
+		t.Fatalf("creating Client: %v", err)
+	}
+	defer travelTimeCli.Delete()
+	travelTimes, distances = travelTimeCli.MatrixWithDistances(sources, destinations)
+	expectedDistances = [][]uint32{{1499, 1268, max}, {1893, 577, max}}
+	expectedTravelTimes = [][]uint32{{131599, 110399, max}, {153488, 52230, max}}
+	if !reflect.DeepEqual(expectedDistances, distances) {
+		t.Errorf("expected distances %v, got %v", expectedDistances, distances)
+	}
+	if !reflect.DeepEqual(expectedTravelTimes, travelTimes) {
+		t.Errorf("expected travel times %v, got %v", expectedTravelTimes, travelTimes)
+	}
+	for i, source := range sources {
+		for j, destination := range destinations {
+			travelTime, distance, _ := travelTimeCli.RouteWithDistance(source, destination)
+			if distance != distances[i][j] || travelTime != travelTimes[i][j] {
+				t.Errorf(
+					"[%d][%d] expected route of %d m and %d ms, got %d m and %d ms",
+					i, j, distances[i][j], travelTimes[i][j], distance, travelTime,
+				)
+			}
+		}
+	}
+}
+
 func TestTravelTime(t *testing.T) {
 	tests := []struct {
 		source      []float32
@@ -0,0 +1,124 @@
+package routingkit
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// SparseCell is a cell of a sparse matrix: the cost from the source with
+// index Source to the target with index Target.
+type SparseCell struct {
+	Source int
+	Target int
+	Cost   uint32
+}
+
+// MatrixWithin is like MatrixContext, but only returns the cells whose cost is
+// at most maxCost, ordered by source and target. The searches on the
+// contraction hierarchy stop at maxCost, so this is much faster than
+// computing the whole matrix if most pairs of points are far from each other.
+// Pairs that are not reachable within maxCost, including those with a point
+// that cannot be snapped, are left out. If ctx is done while the cells are
+// computed, the search stops, no cells are returned and the returned error is
+// ctx.Err().
+func (c client) MatrixWithin(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+	maxCost uint32,
+) ([]SparseCell, error) {
+	s, err := pointsFromSlices(sources)
+	if err != nil {
+		return nil, err
+	}
+	t, err := pointsFromSlices(targets)
+	if err != nil {
+		return nil, err
+	}
+	return c.sparseMatrix(ctx, s, t, maxCost)
+}
+
+// DistancesWithin is like MatrixWithin for a single source, so the Source of
+// all returned cells is 0.
+func (c client) DistancesWithin(
+	ctx context.Context,
+	source []float32,
+	targets [][]float32,
+	maxCost uint32,
+) ([]SparseCell, error) {
+	return c.MatrixWithin(ctx, [][]float32{source}, targets, maxCost)
+}
+
+func (c client) sparseMatrix(
+	ctx context.Context,
+	sources []Point,
+	targets []Point,
+	maxCost uint32,
+) ([]SparseCell, error) {
+	n := len(sources)
+	if len(targets) > n {
+		n = len(targets)
+	}
+	slots, err := c.acquireAll(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	defer c.releaseAll(slots)
+	if len(sources) == 0 || len(targets) == 0 {
+		return []SparseCell{}, nil
+	}
+
+	slotsVector := toSwigInts(slots)
+	defer routingkit.DeleteIntVector(slotsVector)
+	targetsVector := toSwigPoints(targets)
+	defer routingkit.DeletePointVector(targetsVector)
+	buckets := c.client.Target_buckets(slotsVector, c.snapRadius, targetsVector, uint(maxCost))
+	defer routingkit.DeleteTargetBuckets(buckets)
+
+	sourcesVector := toSwigPoints(sources)
+	defer routingkit.DeletePointVector(sourcesVector)
+	cancelled, stop := cancellation(ctx)
+	resp := c.client.Sparse_many_to_many(slotsVector, buckets, c.snapRadius, sourcesVector, uint(maxCost), intBuffer(cancelled))
+	stop()
+	runtime.KeepAlive(cancelled)
+	defer routingkit.DeleteUnsignedVector(resp)
+	if atomic.LoadInt32(&cancelled[0]) != 0 {
+		return nil, ctx.Err()
+	}
+
+	values := toUint32s(resp)
+	cells := make([]SparseCell, len(values)/3)
+	for i := range cells {
+		cells[i] = SparseCell{
+			Source: int(values[3*i]),
+			Target: int(values[3*i+1]),
+			Cost:   values[3*i+2],
+		}
+	}
+	return cells, nil
+}
+
+// MatrixWithin is like MatrixContext, but only returns the cells whose travel
+// time is at most maxCost, ordered by source and target.
+func (c TravelTimeClient) MatrixWithin(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+	maxCost uint32,
+) ([]SparseCell, error) {
+	return c.client.MatrixWithin(ctx, sources, targets, maxCost)
+}
+
+// DistancesWithin is like MatrixWithin for a single source, so the Source of
+// all returned cells is 0.
+func (c TravelTimeClient) DistancesWithin(
+	ctx context.Context,
+	source []float32,
+	targets [][]float32,
+	maxCost uint32,
+) ([]SparseCell, error) {
+	return c.client.DistancesWithin(ctx, source, targets, maxCost)
+}
@@ -0,0 +1,272 @@
+package routingkit
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// MatrixFormat is the encoding of the rows written by WriteMatrix.
+type MatrixFormat string
+
+const (
+	// MatrixNDJSON writes every row as a JSON array of costs on a line of
+	// its own.
+	MatrixNDJSON MatrixFormat = "ndjson"
+	// MatrixBinary writes every row as one little-endian uint32 per target,
+	// without any separator between the rows.
+	MatrixBinary MatrixFormat = "binary"
+)
+
+// streamBatchRows is the number of rows computed by a single forward search
+// batch of StreamMatrix.
+const streamBatchRows = 64
+
+// StreamMatrix computes the matrix of the minimum distances from the sources
+// to the targets and calls fn with every row, in order, as soon as it is
+// available. Batches of rows are computed in parallel, up to as many as the
+// client allows concurrent queries, while earlier rows are passed to fn, so
+// only a few rows are held in memory at a time. fn may keep costs.
+//
+// If fn returns an error, the computation stops and the error is returned. If
+// ctx is done before all rows are passed to fn, ctx.Err() is returned. It also
+// returns an error if a point is invalid.
+func (c client) StreamMatrix(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+	fn func(row int, costs []uint32) error,
+) error {
+	s, err := pointsFromSlices(sources)
+	if err != nil {
+		return err
+	}
+	t, err := pointsFromSlices(targets)
+	if err != nil {
+		return err
+	}
+	return c.streamMatrix(ctx, s, t, fn)
+}
+
+// WriteMatrix is like StreamMatrix, but writes the rows to w in the given
+// format.
+func (c client) WriteMatrix(
+	ctx context.Context,
+	w io.Writer,
+	sources [][]float32,
+	targets [][]float32,
+	format MatrixFormat,
+) error {
+	var encode func(buf []byte, costs []uint32) []byte
+	switch format {
+	case MatrixNDJSON:
+		encode = appendNDJSONRow
+	case MatrixBinary:
+		encode = appendBinaryRow
+	default:
+		return fmt.Errorf("unknown matrix format %v", format)
+	}
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	err := c.StreamMatrix(ctx, sources, targets, func(_ int, costs []uint32) error {
+		buf = encode(buf[:0], costs)
+		_, err := bw.Write(buf)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// appendNDJSONRow appends the costs as a JSON array followed by a newline.
+func appendNDJSONRow(buf []byte, costs []uint32) []byte {
+	buf = append(buf, '[')
+	for i, cost := range costs {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendUint(buf, uint64(cost), 10)
+	}
+	return append(buf, ']', '\n')
+}
+
+// appendBinaryRow appends the costs as little-endian uint32 values.
+func appendBinaryRow(buf []byte, costs []uint32) []byte {
+	var b [4]byte
+	for _, cost := range costs {
+		binary.LittleEndian.PutUint32(b[:], cost)
+		buf = append(buf, b[:]...)
+	}
+	return buf
+}
+
+func (c client) streamMatrix(
+	ctx context.Context,
+	sources []Point,
+	targets []Point,
+	fn func(row int, costs []uint32) error,
+) error {
+	if len(sources) == 0 {
+		return nil
+	}
+	if len(targets) == 0 {
+		for i := range sources {
+			if err := fn(i, []uint32{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	buckets, err := c.targetBuckets(ctx, targets)
+	if err != nil {
+		return err
+	}
+	defer routingkit.DeleteTargetBuckets(buckets)
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	// the batches must be done before the buckets are deleted
+	defer wg.Wait()
+	defer cancel()
+
+	batches := (len(sources) + streamBatchRows - 1) / streamBatchRows
+	results := make([]chan []uint32, batches)
+	for b := range results {
+		results[b] = make(chan []uint32, 1)
+	}
+	// ahead limits the number of batches computed before they are passed to
+	// fn
+	ahead := make(chan struct{}, 2*cap(c.channel))
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for b := 0; b < batches; b++ {
+			select {
+			case ahead <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func(b int) {
+				defer wg.Done()
+				from, to := b*streamBatchRows, (b+1)*streamBatchRows
+				if to > len(sources) {
+					to = len(sources)
+				}
+				cells := make([]uint32, (to-from)*len(targets))
+				if err := c.manyToManyRows(ctx, buckets, sources[from:to], cells); err != nil {
+					return
+				}
+				results[b] <- cells
+			}(b)
+		}
+	}()
+
+	for b := 0; b < batches; b++ {
+		var cells []uint32
+		select {
+		case cells = <-results[b]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		for i, row := range toRows(cells, len(cells)/len(targets), len(targets)) {
+			if err := fn(b*streamBatchRows+i, row); err != nil {
+				return err
+			}
+		}
+		<-ahead
+	}
+	return nil
+}
+
+// targetBuckets runs the backward searches from the targets of a
+// many-to-many search on one thread per query slot it takes up, which are as
+// many as are free, up to one per target. The caller must delete the returned
+// buckets.
+func (c client) targetBuckets(ctx context.Context, targets []Point) (routingkit.TargetBuckets, error) {
+	slots, err := c.acquireAll(ctx, len(targets))
+	if err != nil {
+		return nil, err
+	}
+	defer c.releaseAll(slots)
+
+	slotsVector := toSwigInts(slots)
+	defer routingkit.DeleteIntVector(slotsVector)
+	targetsVector := toSwigPoints(targets)
+	defer routingkit.DeletePointVector(targetsVector)
+	return c.client.Target_buckets(slotsVector, c.snapRadius, targetsVector, uint(MaxDistance)), nil
+}
+
+// manyToManyRows computes the rows of the sources to the targets of the
+// buckets on a single thread and stores them in distances, which must hold one
+// element per cell. It stops once ctx is done, in which case ctx.Err() is
+// returned.
+func (c client) manyToManyRows(
+	ctx context.Context,
+	buckets routingkit.TargetBuckets,
+	sources []Point,
+	distances []uint32,
+) error {
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.release(counter)
+
+	slotsVector := toSwigInts([]int{counter})
+	defer routingkit.DeleteIntVector(slotsVector)
+	sourcesVector := toSwigPoints(sources)
+	defer routingkit.DeletePointVector(sourcesVector)
+	cancelled, stop := cancellation(ctx)
+	computed := int(c.client.Many_to_many_rows(
+		slotsVector,
+		buckets,
+		c.snapRadius,
+		sourcesVector,
+		uintBuffer(distances),
+		intBuffer(nil),
+		uintBuffer(nil),
+		uintBuffer(nil),
+		intBuffer(cancelled),
+	))
+	stop()
+	runtime.KeepAlive(distances)
+	runtime.KeepAlive(cancelled)
+	if computed < len(sources) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// StreamMatrix computes the matrix of the minimum travel times from the
+// sources to the targets and calls fn with every row, in order, as soon as it
+// is available.
+func (c TravelTimeClient) StreamMatrix(
+	ctx context.Context,
+	sources [][]float32,
+	targets [][]float32,
+	fn func(row int, costs []uint32) error,
+) error {
+	return c.client.StreamMatrix(ctx, sources, targets, fn)
+}
+
+// WriteMatrix is like StreamMatrix, but writes the rows to w in the given
+// format.
+func (c TravelTimeClient) WriteMatrix(
+	ctx context.Context,
+	w io.Writer,
+	sources [][]float32,
+	targets [][]float32,
+	format MatrixFormat,
+) error {
+	return c.client.WriteMatrix(ctx, w, sources, targets, format)
+}
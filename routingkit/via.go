@@ -0,0 +1,152 @@
+package routingkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nextmv-io/go-routingkit/routingkit/internal/routingkit"
+)
+
+// Stop is a point a route passes through.
+type Stop struct {
+	Point Point
+	// PassThrough means that the route passes through the point without
+	// stopping, so it must not make a U-turn there. Otherwise the point is a
+	// stopover at which the route may turn around. It is ignored for the
+	// first and last stop.
+	PassThrough bool
+}
+
+// ViaRoute describes a route through several stops.
+type ViaRoute struct {
+	// Cost is the sum of the costs of the legs. It is MaxDistance if any leg
+	// is not reachable.
+	Cost uint32
+	// Distance and TravelTime are the length in meters and the travel time in
+	// milliseconds of the whole route.
+	Distance   uint32
+	TravelTime uint32
+	// Legs are the routes between consecutive stops.
+	Legs []RouteResult
+	// Waypoints describe the whole route, stitched together from the
+	// waypoints of the legs.
+	Waypoints []Point
+	// Reachable is false if any leg is not reachable.
+	Reachable bool
+}
+
+// RouteVia finds the route through the points in the given order, stopping at
+// every point. It returns the total cost, the cost of each leg and the
+// waypoints describing the whole route.
+func (c client) RouteVia(points [][]float32) (uint32, []uint32, [][]float32) {
+	cost, legs, waypoints, _ := c.RouteViaContext(context.Background(), points)
+	return cost, legs, waypoints
+}
+
+// RouteViaContext is like RouteVia, but gives up waiting for a free query
+// slot when ctx is done, in which case ctx.Err() is returned. It also returns
+// an error if a point is invalid or if there are less than two points.
+func (c client) RouteViaContext(ctx context.Context, points [][]float32) (uint32, []uint32, [][]float32, error) {
+	converted, err := pointsFromSlices(points)
+	if err != nil {
+		return MaxDistance, nil, nil, err
+	}
+	stops := make([]Stop, len(converted))
+	for i, p := range converted {
+		stops[i] = Stop{Point: p}
+	}
+	route, err := c.FindRouteVia(ctx, stops)
+	if err != nil {
+		return MaxDistance, nil, nil, err
+	}
+	legs := make([]uint32, len(route.Legs))
+	for i, leg := range route.Legs {
+		legs[i] = leg.Cost
+	}
+	return route.Cost, legs, toSlices(route.Waypoints), nil
+}
+
+// FindRouteVia finds the route through the stops in the given order. It
+// returns an error if a point is invalid, if there are less than two stops or
+// if ctx is done before a free query slot becomes available.
+func (c client) FindRouteVia(ctx context.Context, stops []Stop) (ViaRoute, error) {
+	if len(stops) < 2 {
+		return ViaRoute{}, fmt.Errorf("a route needs at least two stops, got %v", len(stops))
+	}
+	for _, s := range stops {
+		if err := s.Point.Validate(); err != nil {
+			return ViaRoute{}, err
+		}
+	}
+
+	counter, err := c.acquire(ctx)
+	if err != nil {
+		return ViaRoute{}, err
+	}
+	defer c.release(counter)
+
+	route := ViaRoute{Legs: make([]RouteResult, 0, len(stops)-1), Reachable: true}
+	// the position at which the previous leg ended
+	arc := invalidArc
+	var fraction float32
+	for i := 1; i < len(stops); i++ {
+		from, to := stops[i-1], stops[i]
+		var resp routingkit.QueryResponse
+		if i > 1 && from.PassThrough && arc != invalidArc {
+			resp = c.client.Continue_query(
+				counter, c.snapRadius, arc, fraction, to.Point.Lon, to.Point.Lat, true, false,
+			)
+		} else {
+			resp = c.client.Query(
+				counter, c.snapRadius,
+				from.Point.Lon, from.Point.Lat, 0, 0, to.Point.Lon, to.Point.Lat, 0, 0,
+				true, false,
+			)
+		}
+		leg := routeResult(resp)
+		// a leg without any arc ends where the previous one did
+		if !leg.Reachable || resp.GetLast_arc() != invalidArc {
+			arc, fraction = resp.GetLast_arc(), resp.GetLast_fraction()
+		}
+		routingkit.DeleteQueryResponse(resp)
+
+		route.Legs = append(route.Legs, leg)
+		if !leg.Reachable {
+			route.Reachable = false
+			continue
+		}
+		route.Cost += leg.Cost
+		route.Distance += leg.Distance
+		route.TravelTime += leg.TravelTime
+		waypoints := leg.Waypoints
+		if n := len(route.Waypoints); n > 0 && len(waypoints) > 0 && route.Waypoints[n-1] == waypoints[0] {
+			waypoints = waypoints[1:]
+		}
+		route.Waypoints = append(route.Waypoints, waypoints...)
+	}
+	if !route.Reachable {
+		route.Cost, route.Distance, route.TravelTime = MaxDistance, MaxDistance, MaxDistance
+	}
+	return route, nil
+}
+
+// RouteVia finds the fastest route through the points in the given order,
+// stopping at every point. It returns the total travel time, the travel time
+// of each leg and the waypoints describing the whole route.
+func (c TravelTimeClient) RouteVia(points [][]float32) (uint32, []uint32, [][]float32) {
+	return c.client.RouteVia(points)
+}
+
+// RouteViaContext is like RouteVia, but gives up waiting for a free query
+// slot when ctx is done, in which case ctx.Err() is returned. It also returns
+// an error if a point is invalid or if there are less than two points.
+func (c TravelTimeClient) RouteViaContext(ctx context.Context, points [][]float32) (uint32, []uint32, [][]float32, error) {
+	return c.client.RouteViaContext(ctx, points)
+}
+
+// FindRouteVia finds the fastest route through the stops in the given order.
+// It returns an error if a point is invalid, if there are less than two stops
+// or if ctx is done before a free query slot becomes available.
+func (c TravelTimeClient) FindRouteVia(ctx context.Context, stops []Stop) (ViaRoute, error) {
+	return c.client.FindRouteVia(ctx, stops)
+}
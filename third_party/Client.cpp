@@ -5,6 +5,7 @@
 #include <routingkit/geo_position_to_node.h>
 #include <routingkit/osm_graph_builder.h>
 #include <routingkit/osm_profile.h>
+#include <routingkit/geo_dist.h>
 #include "Client.h"
 #include <fstream>
 #include <iostream>
@@ -12,11 +13,20 @@
 #include <thread>
 #include <future>
 #include <unordered_set>
+#include <queue>
+#include <algorithm>
+#include <atomic>
 #include <vector>
 #include <execinfo.h>
 #include <signal.h>
+#ifndef __APPLE__
+#include <ucontext.h>
+#endif
+#include <poll.h>
 #include <unistd.h>
 #include <stdexcept>
+#include <limits>
+#include <cmath>
 
 using namespace RoutingKit;
 using namespace GoRoutingKit;
@@ -25,6 +35,7 @@ using namespace std;
 namespace GoRoutingKit
 {
     const unsigned max_distance = inf_weight;
+    const unsigned invalid_arc = invalid_id;
 
     void log_message(const std::string &msg)
     {
@@ -270,8 +281,50 @@ namespace GoRoutingKit
                 on_new_turn_restriction(OSMTurnRestriction{osm_relation_id, restriction_type, turn_direction, member_list[from_member].id, via_node, member_list[to_member].id});
     }
 
+    // coordinate_unit is the precision in degrees of the encoded modelling
+    // nodes, which is the precision of OpenStreetMap coordinates.
+    const double coordinate_unit = 1e-7;
+
+    long long to_coordinate_units(float degrees)
+    {
+        return llround(degrees / coordinate_unit);
+    }
+
+    // append_varint appends the value to the bytes in zigzag encoding, seven
+    // bits per byte, with the highest bit set on every byte but the last.
+    void append_varint(vector<unsigned char> &bytes, long long value)
+    {
+        unsigned long long zigzag = ((unsigned long long)value << 1) ^ (unsigned long long)(value >> 63);
+        while (zigzag >= 0x80)
+        {
+            bytes.push_back((unsigned char)(zigzag | 0x80));
+            zigzag >>= 7;
+        }
+        bytes.push_back((unsigned char)zigzag);
+    }
+
+    // read_varint reads a value written by append_varint and advances bytes
+    // past it.
+    long long read_varint(const unsigned char *&bytes)
+    {
+        unsigned long long zigzag = 0;
+        for (unsigned shift = 0;; shift += 7)
+        {
+            unsigned char b = *bytes++;
+            zigzag |= (unsigned long long)(b & 0x7f) << shift;
+            if (b < 0x80)
+                break;
+        }
+        return (long long)(zigzag >> 1) ^ -(long long)(zigzag & 1);
+    }
+
+    // load_custom_osm_routing_graph_from_pbf loads the routing graph of the
+    // profile. on_way is called for every routing way. If geometry is set, the
+    // modelling nodes of the arcs are loaded as well.
     RoutingGraph load_custom_osm_routing_graph_from_pbf(
-        const std::string &pbf_file, Profile profile)
+        const std::string &pbf_file, Profile profile,
+        std::function<void(uint64_t osm_way_id, unsigned routing_way_id, const TagMap &way_tags)> on_way,
+        bool geometry)
     {
         bool all_modelling_nodes_are_routing_nodes = false;
         bool file_is_ordered_even_though_file_header_says_that_it_is_unordered = false;
@@ -327,6 +380,7 @@ namespace GoRoutingKit
             mapping,
             [&](uint64_t osm_way_id, unsigned routing_way_id, const TagMap &way_tags)
             {
+                on_way(osm_way_id, routing_way_id, way_tags);
                 if (profile.waySpeeds.find(osm_way_id) == profile.waySpeeds.end())
                 {
                     waySpeeds[routing_way_id] = get_osm_way_speed(osm_way_id, way_tags, log_message);
@@ -347,7 +401,9 @@ namespace GoRoutingKit
                 return get_osm_car_direction_category(osm_way_id, way_tags, log_message);
             },
             turn_restriction_decoder,
-            log_message);
+            log_message,
+            file_is_ordered_even_though_file_header_says_that_it_is_unordered,
+            geometry ? OSMRoadGeometry::uncompressed : OSMRoadGeometry::none);
 
         mapping = OSMRoutingIDMapping(); // release memory
 
@@ -358,6 +414,7 @@ namespace GoRoutingKit
         ret.latitude = std::move(routing_graph.latitude);
         ret.longitude = std::move(routing_graph.longitude);
 
+        ret.way_speed = waySpeeds;
         ret.travel_time = ret.geo_distance;
         for (unsigned a = 0; a < ret.travel_time.size(); ++a)
         {
@@ -369,11 +426,207 @@ namespace GoRoutingKit
         ret.forbidden_turn_from_arc = std::move(routing_graph.forbidden_turn_from_arc);
         assert(is_sorted_using_less(ret.forbidden_turn_from_arc));
         ret.forbidden_turn_to_arc = std::move(routing_graph.forbidden_turn_to_arc);
+        ret.way = std::move(routing_graph.way);
+        // RoutingKit extracts some modelling nodes to decode turn restrictions
+        // even if no geometry was requested, so they are only kept on request,
+        // encoded as the differences between consecutive nodes, which take
+        // about four bytes per node instead of eight.
+        if (geometry)
+        {
+            ret.first_modelling_byte.assign(ret.arc_count() + 1, 0);
+            for (unsigned x = 0; x < ret.node_count(); ++x)
+                for (unsigned a = ret.first_out[x]; a < ret.first_out[x + 1]; ++a)
+                {
+                    long long lat = to_coordinate_units(ret.latitude[x]), lon = to_coordinate_units(ret.longitude[x]);
+                    for (unsigned m = routing_graph.first_modelling_node[a]; m < routing_graph.first_modelling_node[a + 1]; ++m)
+                    {
+                        long long next_lat = to_coordinate_units(routing_graph.modelling_node_latitude[m]);
+                        long long next_lon = to_coordinate_units(routing_graph.modelling_node_longitude[m]);
+                        append_varint(ret.modelling_bytes, next_lat - lat);
+                        append_varint(ret.modelling_bytes, next_lon - lon);
+                        lat = next_lat;
+                        lon = next_lon;
+                    }
+                    ret.first_modelling_byte[a + 1] = ret.modelling_bytes.size();
+                }
+            ret.modelling_bytes.shrink_to_fit();
+        }
 
         return ret;
     }
 }
 
+namespace
+{
+    const double earth_radius = 6371000;
+
+    // Projection describes the point on a segment closest to a query point.
+    struct Projection
+    {
+        // fraction is the position of the point on the segment between 0
+        // (start) and 1 (end).
+        double fraction;
+        // distance is the distance in meters from the query point.
+        double distance;
+    };
+
+    // project projects the query point onto the segment from a to b. The
+    // segment is assumed to be short enough to be treated as planar.
+    Projection project(float lat, float lon, float a_lat, float a_lon, float b_lat, float b_lon)
+    {
+        double scale = cos(lat * M_PI / 180);
+        double ax = (a_lon - lon) * scale, ay = a_lat - lat;
+        double bx = (b_lon - lon) * scale, by = b_lat - lat;
+        double dx = bx - ax, dy = by - ay;
+        double length = dx * dx + dy * dy;
+        double fraction = length == 0 ? 0 : -(ax * dx + ay * dy) / length;
+        fraction = max(0.0, min(1.0, fraction));
+        double x = ax + fraction * dx, y = ay + fraction * dy;
+        return Projection{fraction, sqrt(x * x + y * y) * M_PI / 180 * earth_radius};
+    }
+
+    // arc_bearing returns the initial bearing in degrees clockwise from north,
+    // between 0 and 360, of the great circle from a to b.
+    double arc_bearing(float a_lat, float a_lon, float b_lat, float b_lon)
+    {
+        double phi_a = a_lat * M_PI / 180, phi_b = b_lat * M_PI / 180;
+        double lambda = (b_lon - a_lon) * M_PI / 180;
+        double bearing = atan2(sin(lambda) * cos(phi_b), cos(phi_a) * sin(phi_b) - sin(phi_a) * cos(phi_b) * cos(lambda)) * 180 / M_PI;
+        return bearing < 0 ? bearing + 360 : bearing;
+    }
+
+    // angle_between returns the difference in degrees between the bearings,
+    // between 0 and 180.
+    double angle_between(double a, double b)
+    {
+        double difference = fmod(fabs(a - b), 360);
+        return min(difference, 360 - difference);
+    }
+
+    // project_polyline projects the query point onto the polyline through the
+    // points. The fraction of the projection is its distance along the
+    // polyline divided by the length of the polyline. If tolerance is greater
+    // than zero, the point is only projected onto the pieces of the polyline
+    // whose bearing differs from the given one by at most tolerance degrees,
+    // and the distance is infinite if there are none.
+    Projection project_polyline(float lat, float lon, const vector<Point> &points, double bearing = 0, double tolerance = 0)
+    {
+        Projection nearest{0, numeric_limits<double>::infinity()};
+        double length = 0, along = 0;
+        for (unsigned i = 1; i < points.size(); ++i)
+        {
+            const Point &a = points[i - 1], &b = points[i];
+            double piece = geo_dist(a.lat, a.lon, b.lat, b.lon);
+            Projection p = project(lat, lon, a.lat, a.lon, b.lat, b.lon);
+            if (tolerance > 0 && angle_between(arc_bearing(a.lat, a.lon, b.lat, b.lon), bearing) > tolerance)
+                p.distance = numeric_limits<double>::infinity();
+            if (p.distance < nearest.distance)
+            {
+                nearest.distance = p.distance;
+                along = length + p.fraction * piece;
+            }
+            length += piece;
+        }
+        nearest.fraction = length == 0 ? 0 : along / length;
+        return nearest;
+    }
+
+    // polyline_fractions stores the position of every point of the polyline
+    // through the points as its distance along the polyline divided by the
+    // length of the polyline.
+    void polyline_fractions(const vector<Point> &points, vector<double> &fractions)
+    {
+        fractions.assign(points.size(), 0);
+        for (unsigned i = 1; i < points.size(); ++i)
+            fractions[i] = fractions[i - 1] + geo_dist(points[i - 1].lat, points[i - 1].lon, points[i].lat, points[i].lon);
+        double length = fractions.back();
+        for (auto &f : fractions)
+            f = length == 0 ? 0 : f / length;
+    }
+
+    // arc_grid_cell_size is the side length in degrees of the cells of the
+    // arc grid.
+    const double arc_grid_cell_size = 0.01;
+
+    long long arc_grid_cell(long long row, long long column)
+    {
+        return (row << 32) ^ (column & 0xffffffff);
+    }
+
+    // piece_bearing returns the bearing of the piece of the polyline through
+    // the points that leaves the position at the given fraction or, if
+    // entering is set, that enters it. fractions are the positions of the
+    // points, and pieces of zero length are skipped.
+    double piece_bearing(const vector<Point> &points, const vector<double> &fractions, double fraction, bool entering)
+    {
+        unsigned piece = 0;
+        for (unsigned i = 1; i < points.size(); ++i)
+        {
+            if (fractions[i] == fractions[i - 1])
+                continue;
+            if (entering)
+            {
+                if (piece == 0 || fractions[i - 1] < fraction)
+                    piece = i;
+            }
+            else
+            {
+                piece = i;
+                if (fractions[i] > fraction)
+                    break;
+            }
+        }
+        if (piece == 0)
+            return arc_bearing(points.front().lat, points.front().lon, points.back().lat, points.back().lon);
+        return arc_bearing(points[piece - 1].lat, points[piece - 1].lon, points[piece].lat, points[piece].lon);
+    }
+
+    // Offset is a routing node a snapped point is connected to, together with
+    // the part of each metric between the point and the node.
+    struct Offset
+    {
+        unsigned node;
+        unsigned weight;
+        unsigned geo_distance;
+        unsigned travel_time;
+    };
+
+    // Placement is the position of a snapped point on an arc.
+    struct Placement
+    {
+        unsigned arc;
+        double fraction;
+    };
+
+    // total adds up the parts of a path, saturating at inf_weight.
+    unsigned total(unsigned long long a, unsigned long long b, unsigned long long c)
+    {
+        if (a >= inf_weight || b >= inf_weight || c >= inf_weight)
+            return inf_weight;
+        return (unsigned)min<unsigned long long>(a + b + c, inf_weight);
+    }
+}
+
+namespace GoRoutingKit
+{
+    struct SnappedPoint
+    {
+        bool snapped = false;
+        Point point{0, 0};
+        // node is the node the point was snapped to if points are snapped to
+        // nodes, and arc the arc it was projected onto otherwise.
+        unsigned node = invalid_id;
+        unsigned arc = invalid_id;
+        // placements holds the position of the point on arc and on the arcs
+        // in the opposite direction, if any.
+        std::vector<Placement> placements;
+        // sources are the nodes paths starting at the point continue from,
+        // and targets are the nodes paths ending at the point come from.
+        std::vector<Offset> sources;
+        std::vector<Offset> targets;
+    };
+}
+
 bool file_exists(char *file)
 {
     ifstream f;
@@ -383,144 +636,702 @@ bool file_exists(char *file)
 
 namespace ErrorHandler
 {
+    // file descriptor that crash diagnostics are written to
+    volatile sig_atomic_t diagnostics_fd = STDERR_FILENO;
+    // file descriptor that is hung up once the crash diagnostics were
+    // forwarded by the reader of diagnostics_fd, or -1 if they are not
+    // forwarded
+    volatile sig_atomic_t forwarded_fd = -1;
+
     void dump_stack(int sig)
     {
-        fprintf(stderr, "Error: signal %d:\n", sig);
+        dprintf(diagnostics_fd, "Error: signal %d:\n", sig);
 
         void *array[20];
-        size_t size;
+        int size;
 
         // get void*'s for all entries on the stack
-        size = backtrace(array, sizeof(array));
+        size = backtrace(array, sizeof(array) / sizeof(array[0]));
 
-        // print out all the frames to stderr
-        backtrace_symbols_fd(array, size, STDERR_FILENO);
+        // print out all the frames to the diagnostics file descriptor
+        backtrace_symbols_fd(array, size, diagnostics_fd);
+    }
+
+    // addresses of the machine code of the Go functions of the process
+    uintptr_t go_text_start = 0, go_text_end = 0;
+    // handlers installed for the signals before, which are those of the Go
+    // runtime
+    struct sigaction go_actions[NSIG];
+
+    // program_counter returns the address of the instruction that was running
+    // when the signal described by context was raised.
+    uintptr_t program_counter(void *context)
+    {
+        ucontext_t *uc = (ucontext_t *)context;
+#if defined(__APPLE__) && defined(__x86_64__)
+        return uc->uc_mcontext->__ss.__rip;
+#elif defined(__APPLE__)
+        return uc->uc_mcontext->__ss.__pc;
+#elif defined(__x86_64__)
+        return uc->uc_mcontext.gregs[REG_RIP];
+#else
+        return uc->uc_mcontext.pc;
+#endif
     }
 
-    void exception_handler(int sig)
+    void exception_handler(int sig, siginfo_t *info, void *context)
     {
+        // faults in Go code are left to the Go runtime, which e.g. turns a nil
+        // pointer dereference into a panic. Signals sent by a process, whose
+        // si_code is not positive, are not faults.
+        uintptr_t pc = program_counter(context);
+        if (info->si_code > 0 && pc >= go_text_start && pc < go_text_end && (go_actions[sig].sa_flags & SA_SIGINFO))
+        {
+            go_actions[sig].sa_sigaction(sig, info, context);
+            return;
+        }
+
         dump_stack(sig);
-        exit(1);
+        if (forwarded_fd >= 0)
+        {
+            // closing diagnostics_fd ends the stack trace for its reader.
+            // The reader may not get to run after a crash, so it is waited
+            // for at most a second.
+            close(diagnostics_fd);
+            struct pollfd forwarded = {forwarded_fd, POLLIN, 0};
+            poll(&forwarded, 1, 1000);
+        }
+        // the signal is delivered again with the default action once the
+        // handler returns, which terminates the process as usual
+        signal(sig, SIG_DFL);
+        raise(sig);
     }
 
-    void install_exception_handlers()
+    void install_exception_handlers(int fd, int forwarded, uintptr_t text_start, uintptr_t text_end)
     {
-        signal(SIGSEGV, exception_handler);
-        signal(SIGBUS, exception_handler);
-        signal(SIGINT, exception_handler);
-        signal(SIGQUIT, exception_handler);
-        signal(SIGILL, exception_handler);
-        signal(SIGABRT, exception_handler);
-        signal(SIGFPE, exception_handler);
-        signal(SIGTERM, exception_handler);
-        signal(SIGSYS, exception_handler);
+        diagnostics_fd = fd;
+        forwarded_fd = forwarded;
+        go_text_start = text_start;
+        go_text_end = text_end;
+
+        struct sigaction action;
+        memset(&action, 0, sizeof(action));
+        action.sa_sigaction = exception_handler;
+        sigemptyset(&action.sa_mask);
+        // the Go runtime requires handlers to run on the alternate signal stack
+        action.sa_flags = SA_ONSTACK | SA_SIGINFO;
+
+        for (int sig : {SIGSEGV, SIGBUS, SIGILL, SIGABRT, SIGFPE, SIGSYS})
+        {
+            struct sigaction previous;
+            sigaction(sig, &action, &previous);
+            // installing the handlers again keeps the handlers of the Go
+            // runtime
+            if (previous.sa_sigaction != exception_handler)
+                go_actions[sig] = previous;
+        }
+    }
+}
+
+void GoRoutingKit::install_crash_handlers(int fd, int forwarded_fd, unsigned long long go_text_start, unsigned long long go_text_end)
+{
+    ErrorHandler::install_exception_handlers(fd, forwarded_fd, go_text_start, go_text_end);
+}
 
-        signal(SIGUSR1, dump_stack);
+RoadNetwork::RoadNetwork(char *pbf_file, Profile profile, bool geometry)
+{
+    try
+    {
+        // Load a routing graph from OpenStreetMap-based data
+        graph = load_custom_osm_routing_graph_from_pbf(
+            pbf_file, profile,
+            [this](uint64_t osm_way_id, unsigned routing_way_id, const TagMap &tags)
+            {
+                if (routing_way_id >= way_osm_id.size())
+                {
+                    way_osm_id.resize(routing_way_id + 1);
+                    way_name.resize(routing_way_id + 1);
+                    way_ref.resize(routing_way_id + 1);
+                    way_highway.resize(routing_way_id + 1);
+                    way_roundabout.resize(routing_way_id + 1);
+                }
+                const char *name = tags["name"];
+                const char *ref = tags["ref"];
+                const char *highway = tags["highway"];
+                way_osm_id[routing_way_id] = osm_way_id;
+                way_name[routing_way_id] = name != nullptr ? name : "";
+                way_ref[routing_way_id] = ref != nullptr ? ref : "";
+                way_highway[routing_way_id] = highway != nullptr ? highway : "";
+                const char *junction = tags["junction"];
+                way_roundabout[routing_way_id] = junction != nullptr && (str_eq(junction, "roundabout") || str_eq(junction, "circular"));
+            },
+            geometry);
+        map = GeoPositionToNode{graph.latitude, graph.longitude};
+
+        tail = invert_inverse_vector(graph.first_out);
+        // group the arcs by their head to find the arcs entering a node
+        first_in.assign(graph.node_count() + 1, 0);
+        for (unsigned a = 0; a < graph.arc_count(); ++a)
+            ++first_in[graph.head[a] + 1];
+        for (unsigned x = 0; x < graph.node_count(); ++x)
+            first_in[x + 1] += first_in[x];
+        in_arc.resize(graph.arc_count());
+        vector<unsigned> next = first_in;
+        for (unsigned a = 0; a < graph.arc_count(); ++a)
+            in_arc[next[graph.head[a]]++] = a;
+    }
+    catch (const exception &e)
+    {
+        error = e.what();
+        if (error.empty())
+        {
+            error = "unknown error";
+        }
     }
 }
 
-Client::Client(int conc, char *pbf_file, char *ch_file, Profile profile)
+const char *RoadNetwork::load_error() const
 {
-    ErrorHandler::install_exception_handlers();
-    vector<unsigned int> tail;
+    return error.c_str();
+}
 
-    bool ch_exists = file_exists(ch_file);
+// nearest_arc returns the arc leaving or entering node that is closest to the
+// given position, or invalid_id if there is none.
+unsigned RoadNetwork::nearest_arc(unsigned node, float lat, float lon) const
+{
+    unsigned nearest = invalid_id;
+    double nearest_distance = numeric_limits<double>::infinity();
+    vector<Point> points;
+    auto consider = [&](unsigned a)
+    {
+        polyline(a, points);
+        double distance = project_polyline(lat, lon, points).distance;
+        if (distance < nearest_distance)
+        {
+            nearest = a;
+            nearest_distance = distance;
+        }
+    };
+    for (unsigned a = graph.first_out[node]; a < graph.first_out[node + 1]; ++a)
+        consider(a);
+    for (unsigned i = first_in[node]; i < first_in[node + 1]; ++i)
+        consider(in_arc[i]);
+    return nearest;
+}
 
-    // Load a routing graph from OpenStreetMap-based data
-    graph = load_custom_osm_routing_graph_from_pbf(pbf_file, profile);
-    tail = invert_inverse_vector(graph.first_out);
-    if (ch_exists)
+// polyline stores the shape of the arc in points: its tail, its modelling
+// nodes, if they were loaded, and its head.
+void RoadNetwork::polyline(unsigned arc, std::vector<Point> &points) const
+{
+    unsigned x = tail[arc], y = graph.head[arc];
+    points.clear();
+    points.push_back(Point{graph.longitude[x], graph.latitude[x]});
+    if (!graph.first_modelling_byte.empty())
     {
-        ch = ContractionHierarchy::load_file(ch_file);
+        const unsigned char *bytes = graph.modelling_bytes.data() + graph.first_modelling_byte[arc];
+        const unsigned char *end = graph.modelling_bytes.data() + graph.first_modelling_byte[arc + 1];
+        long long lat = to_coordinate_units(graph.latitude[x]), lon = to_coordinate_units(graph.longitude[x]);
+        while (bytes < end)
+        {
+            lat += read_varint(bytes);
+            lon += read_varint(bytes);
+            points.push_back(Point{(float)(lon * coordinate_unit), (float)(lat * coordinate_unit)});
+        }
     }
-    else
+    points.push_back(Point{graph.longitude[y], graph.latitude[y]});
+}
+
+void RoadNetwork::build_arc_grid()
+{
+    call_once(arc_grid_built, [this]()
+              {
+                  vector<Point> points;
+                  for (unsigned a = 0; a < graph.arc_count(); ++a)
+                  {
+                      // the bounding box of the arc's shape
+                      polyline(a, points);
+                      float min_lat = points[0].lat, max_lat = points[0].lat;
+                      float min_lon = points[0].lon, max_lon = points[0].lon;
+                      for (const auto &p : points)
+                      {
+                          min_lat = min(min_lat, p.lat);
+                          max_lat = max(max_lat, p.lat);
+                          min_lon = min(min_lon, p.lon);
+                          max_lon = max(max_lon, p.lon);
+                      }
+                      long long min_row = floor(min_lat / arc_grid_cell_size);
+                      long long max_row = floor(max_lat / arc_grid_cell_size);
+                      long long min_column = floor(min_lon / arc_grid_cell_size);
+                      long long max_column = floor(max_lon / arc_grid_cell_size);
+                      for (long long row = min_row; row <= max_row; ++row)
+                          for (long long column = min_column; column <= max_column; ++column)
+                              arc_grid[arc_grid_cell(row, column)].push_back(a);
+                  } });
+}
+
+// arcs_near returns the arcs in the cells of the arc grid within radius meters
+// of the given position, ordered by ID. They are not necessarily within radius
+// meters of the position.
+std::vector<unsigned> RoadNetwork::arcs_near(float lat, float lon, float radius) const
+{
+    double radius_lat = radius / earth_radius * 180 / M_PI;
+    double radius_lon = radius_lat / max(cos(lat * M_PI / 180), 1e-6);
+    long long min_row = floor((lat - radius_lat) / arc_grid_cell_size);
+    long long max_row = floor((lat + radius_lat) / arc_grid_cell_size);
+    long long min_column = floor((lon - radius_lon) / arc_grid_cell_size);
+    long long max_column = floor((lon + radius_lon) / arc_grid_cell_size);
+
+    vector<unsigned> arcs;
+    for (long long row = min_row; row <= max_row; ++row)
+        for (long long column = min_column; column <= max_column; ++column)
+        {
+            auto cell = arc_grid.find(arc_grid_cell(row, column));
+            if (cell != arc_grid.end())
+                arcs.insert(arcs.end(), cell->second.begin(), cell->second.end());
+        }
+    // arcs can be found in several cells
+    sort(arcs.begin(), arcs.end());
+    arcs.erase(unique(arcs.begin(), arcs.end()), arcs.end());
+    return arcs;
+}
+
+// nearest_arc_within_radius returns the arc closest to the given position
+// within radius meters, or invalid_id if there is none. fraction is set to the
+// position of the projected point along the shape of the arc.
+unsigned RoadNetwork::nearest_arc_within_radius(float lat, float lon, float radius, double &fraction) const
+{
+    unsigned nearest = invalid_id;
+    double nearest_distance = radius;
+    vector<Point> points;
+    for (unsigned a : arcs_near(lat, lon, radius))
+    {
+        polyline(a, points);
+        Projection p = project_polyline(lat, lon, points);
+        // prefer the lower arc ID on ties
+        if (p.distance < nearest_distance || (p.distance == nearest_distance && a < nearest))
+        {
+            nearest = a;
+            nearest_distance = p.distance;
+            fraction = p.fraction;
+        }
+    }
+    return nearest;
+}
+
+Client::Client(int conc, RoadNetwork *network, char *ch_file, bool travel_time, bool snap_to_arcs)
+    : network(network), weight(travel_time ? &network->graph.travel_time : &network->graph.geo_distance), snap_to_arcs(snap_to_arcs)
+{
+    try
     {
-        vector<unsigned> weight = profile.travel_time ? graph.travel_time : graph.geo_distance;
-        ch = ContractionHierarchy::build(graph.node_count(), tail, graph.head, weight);
-        ch.save_file(ch_file);
+        const RoutingGraph &graph = network->graph;
+        if (snap_to_arcs)
+            network->build_arc_grid();
+
+        bool ch_exists = file_exists(ch_file);
+
+        if (ch_exists)
+        {
+            ch = ContractionHierarchy::load_file(ch_file);
+            if (ch.node_count() != graph.node_count())
+            {
+                throw runtime_error(
+                    "contraction hierarchy in " + string(ch_file) + " has " + to_string(ch.node_count()) +
+                    " nodes, but the routing graph has " + to_string(graph.node_count()));
+            }
+        }
+        else
+        {
+            ch = ContractionHierarchy::build(graph.node_count(), network->tail, graph.head, *weight);
+            ch.save_file(ch_file);
+        }
+        // The extra weights allow to sum up both metrics along the paths
+        // found by the contraction hierarchy.
+        this->geo_distance.reset(ch, graph.geo_distance, SaturatedWeightAddition());
+        this->travel_time.reset(ch, graph.travel_time, SaturatedWeightAddition());
+        // Besides the CH itself we need a query object.
+        for (int i = 0; i < conc; i++)
+        {
+            ContractionHierarchyQuery ch_query(ch);
+            queries.push_back(ch_query);
+        }
+        searches.resize(conc);
     }
-    map = GeoPositionToNode{graph.latitude, graph.longitude};
-    // Besides the CH itself we need a query object.
-    for (int i = 0; i < conc; i++)
+    catch (const exception &e)
     {
-        ContractionHierarchyQuery ch_query(ch);
-        queries.push_back(ch_query);
+        error = e.what();
+        if (error.empty())
+        {
+            error = "unknown error";
+        }
     }
 }
 
-Point Client::point(int i)
+const char *Client::load_error() const
+{
+    return error.c_str();
+}
+
+Point Client::point(int i) const
 {
     return Point{
         lon :
-            graph.longitude[i],
-        lat : graph.latitude[i]
+            network->graph.longitude[i],
+        lat : network->graph.latitude[i]
+    };
+}
+
+// add_offsets sets the sources and targets of the snapped point from its
+// placements.
+void Client::add_offsets(SnappedPoint &snapped) const
+{
+    const RoutingGraph &graph = network->graph;
+    auto part = [&](unsigned a, unsigned node, double fraction)
+    {
+        return Offset{
+            node,
+            (unsigned)lround((*weight)[a] * fraction),
+            (unsigned)lround(graph.geo_distance[a] * fraction),
+            (unsigned)lround(graph.travel_time[a] * fraction)};
+    };
+    // keep a single offset per node, as parallel arcs may lead to the same one
+    auto add = [](vector<Offset> &offsets, Offset offset)
+    {
+        for (auto &o : offsets)
+            if (o.node == offset.node)
+            {
+                if (offset.weight < o.weight)
+                    o = offset;
+                return;
+            }
+        offsets.push_back(offset);
     };
+    for (auto placement : snapped.placements)
+    {
+        unsigned a = placement.arc;
+        add(snapped.sources, part(a, graph.head[a], 1 - placement.fraction));
+        add(snapped.targets, part(a, network->tail[a], placement.fraction));
+    }
+}
+
+// position returns the point at the given position along the shape of the
+// arc.
+Point Client::position(unsigned arc, double fraction) const
+{
+    vector<Point> points;
+    vector<double> fractions;
+    network->polyline(arc, points);
+    polyline_fractions(points, fractions);
+    unsigned i = 1;
+    while (i + 1 < points.size() && fractions[i] < fraction)
+        ++i;
+    const Point &a = points[i - 1], &b = points[i];
+    double piece = fractions[i] - fractions[i - 1];
+    double t = piece == 0 ? 0 : max(0.0, min(1.0, (fraction - fractions[i - 1]) / piece));
+    return Point{
+        (float)(a.lon + t * (b.lon - a.lon)),
+        (float)(a.lat + t * (b.lat - a.lat))};
+}
+
+// snap_point snaps the query point to the nearest node or, if the client snaps
+// to arcs, projects it onto the nearest arc.
+SnappedPoint Client::snap_point(float radius, float lon, float lat, float bearing, float tolerance) const
+{
+    const RoutingGraph &graph = network->graph;
+    SnappedPoint snapped;
+    if (tolerance > 0)
+    {
+        // a point with a heading is projected onto the nearest arc in its
+        // direction of travel only, if there is one
+        network->build_arc_grid();
+        unsigned nearest = invalid_id;
+        double nearest_distance = radius, fraction = 0;
+        vector<Point> points;
+        for (unsigned a : network->arcs_near(lat, lon, radius))
+        {
+            // only the pieces of the arc's shape in the direction of travel
+            // are considered
+            network->polyline(a, points);
+            Projection p = project_polyline(lat, lon, points, bearing, tolerance);
+            if (p.distance < nearest_distance || (p.distance == nearest_distance && a < nearest))
+            {
+                nearest = a;
+                nearest_distance = p.distance;
+                fraction = p.fraction;
+            }
+        }
+        if (nearest != invalid_id)
+        {
+            snapped.snapped = true;
+            snapped.arc = nearest;
+            snapped.point = position(nearest, fraction);
+            snapped.placements.push_back(Placement{nearest, fraction});
+            add_offsets(snapped);
+            return snapped;
+        }
+    }
+    if (!snap_to_arcs)
+    {
+        unsigned node = network->map.find_nearest_neighbor_within_radius(lat, lon, radius).id;
+        if (node == invalid_id)
+            return snapped;
+        snapped.snapped = true;
+        snapped.node = node;
+        snapped.point = Point{graph.longitude[node], graph.latitude[node]};
+        snapped.sources.push_back(Offset{node, 0, 0, 0});
+        snapped.targets.push_back(Offset{node, 0, 0, 0});
+        return snapped;
+    }
+
+    double fraction;
+    unsigned arc = network->nearest_arc_within_radius(lat, lon, radius, fraction);
+    if (arc == invalid_id)
+        return snapped;
+    unsigned x = network->tail[arc], y = graph.head[arc];
+    snapped.snapped = true;
+    snapped.arc = arc;
+    snapped.point = position(arc, fraction);
+    snapped.placements.push_back(Placement{arc, fraction});
+    // the point also lies on the arcs of the same road in the opposite
+    // direction
+    for (unsigned a = graph.first_out[y]; a < graph.first_out[y + 1]; ++a)
+        if (graph.head[a] == x)
+            snapped.placements.push_back(Placement{a, 1 - fraction});
+
+    add_offsets(snapped);
+    return snapped;
+}
+
+// append_geometry appends the modelling nodes of the arc between the given
+// positions along it to the waypoints, if they were loaded.
+void Client::append_geometry(unsigned arc, double from, double to, std::vector<Point> &waypoints) const
+{
+    if (network->graph.first_modelling_byte.empty())
+        return;
+    vector<Point> points;
+    vector<double> fractions;
+    network->polyline(arc, points);
+    polyline_fractions(points, fractions);
+    // the first and last points are the tail and the head
+    for (unsigned i = 1; i + 1 < points.size(); ++i)
+        if ((from == 0 || fractions[i] > from) && (to == 1 || fractions[i] < to))
+            waypoints.push_back(points[i]);
+}
+
+// segment describes the part of the arc between the given positions on it.
+Segment Client::segment(unsigned arc, double from, double to) const
+{
+    const RoutingGraph &graph = network->graph;
+    unsigned way = graph.way[arc];
+    unsigned x = network->tail[arc], y = graph.head[arc];
+
+    unsigned branches = 0;
+    if (to == 1)
+        for (unsigned a = graph.first_out[y]; a < graph.first_out[y + 1]; ++a)
+            if (graph.head[a] != x)
+                ++branches;
+
+    vector<Point> points;
+    vector<double> fractions;
+    network->polyline(arc, points);
+    polyline_fractions(points, fractions);
+
+    return Segment{
+        network->way_osm_id[way],
+        network->way_name[way].c_str(),
+        network->way_ref[way].c_str(),
+        network->way_highway[way].c_str(),
+        (unsigned)lround(graph.geo_distance[arc] * (to - from)),
+        (unsigned)lround(graph.travel_time[arc] * (to - from)),
+        graph.way_speed[way],
+        from == 0 ? point(x) : position(arc, from),
+        to == 1 ? point(y) : position(arc, to),
+        (float)piece_bearing(points, fractions, from, false),
+        (float)piece_bearing(points, fractions, to, true),
+        network->way_roundabout[way],
+        branches};
+}
+
+namespace
+{
+    // direct_path finds the shortest path between two points on the same arc
+    // that does not leave the arc. It returns false if there is none. start
+    // and end are set to the positions of the points on the arc.
+    bool direct_path(const RoutingGraph &graph, const vector<unsigned> &weight, const SnappedPoint &source, const SnappedPoint &target, Offset &path, Placement &start, Placement &end)
+    {
+        bool found = false;
+        for (auto s : source.placements)
+            for (auto t : target.placements)
+            {
+                if (s.arc != t.arc || s.fraction > t.fraction)
+                    continue;
+                double fraction = t.fraction - s.fraction;
+                unsigned w = lround(weight[s.arc] * fraction);
+                if (found && w >= path.weight)
+                    continue;
+                found = true;
+                start = s;
+                end = t;
+                path = Offset{
+                    invalid_id,
+                    w,
+                    (unsigned)lround(graph.geo_distance[s.arc] * fraction),
+                    (unsigned)lround(graph.travel_time[s.arc] * fraction)};
+            }
+        return found;
+    }
 }
 
 Point *Client::nearest(int i, float radius, float lon, float lat)
 {
     auto n = [this, i, lon, lat, radius]() -> Point *
     {
-        unsigned neighbor = map.find_nearest_neighbor_within_radius(lat, lon, radius).id;
-        if (neighbor == invalid_id)
+        SnappedPoint snapped = snap_point(radius, lon, lat);
+        if (!snapped.snapped)
             return NULL;
-        Point p = point(neighbor);
-        return new Point(p);
+        return new Point(snapped.point);
     };
 
     return async(launch::deferred, n).get();
 }
 
-std::vector<unsigned> Client::distances(int i, float radius, Point source, std::vector<struct Point> targets)
+SnapResponse Client::snap(int i, float radius, float lon, float lat)
 {
-    auto tbl = [this, i, radius, source, targets]() -> vector<unsigned int>
+    SnapResponse response{};
+    response.name = "";
+    response.highway = "";
+    SnappedPoint snapped = snap_point(radius, lon, lat);
+    if (!snapped.snapped)
+        return response;
+
+    const RoutingGraph &graph = network->graph;
+    response.snapped = true;
+    response.point = snapped.point;
+    response.distance = geo_dist(lat, lon, snapped.point.lat, snapped.point.lon);
+    unsigned arc = snap_to_arcs ? snapped.arc : network->nearest_arc(snapped.node, lat, lon);
+    if (arc != invalid_id)
     {
-        vector<unsigned> results;
-        results.resize(targets.size());
+        unsigned way = graph.way[arc];
+        response.way_id = network->way_osm_id[way];
+        response.name = network->way_name[way].c_str();
+        response.highway = network->way_highway[way].c_str();
+    }
+    return response;
+}
+
+std::vector<unsigned> Client::distances(int i, float radius, Point source, float source_bearing, float source_tolerance,
+                                       std::vector<struct Point> targets, std::vector<float> target_bearings,
+                                       std::vector<float> target_tolerances)
+{
+    return distances_to_targets(i, radius, source, targets, nullptr, false, source_bearing, source_tolerance, target_bearings, target_tolerances);
+}
+
+DistancesResponse Client::detailed_distances(int i, float radius, Point source, std::vector<struct Point> targets, bool include_metrics)
+{
+    DistancesResponse response;
+    response.distances = distances_to_targets(i, radius, source, targets, &response, include_metrics);
+    return response;
+}
 
+// distances_to_targets computes the distances from the source to the targets.
+// If response is not null, the statuses of the queries and, if include_metrics
+// is set, the length and travel time of the paths are stored in it as well.
+std::vector<unsigned> Client::distances_to_targets(int i, float radius, Point source, std::vector<struct Point> targets, DistancesResponse *response, bool include_metrics,
+                                                   float source_bearing, float source_tolerance,
+                                                   std::vector<float> target_bearings, std::vector<float> target_tolerances)
+{
+    bool target_headings = target_bearings.size() == targets.size() && target_tolerances.size() == targets.size();
+    auto tbl = [this, i, radius, source, targets, response, include_metrics, source_bearing, source_tolerance, target_bearings, target_tolerances, target_headings]() -> vector<unsigned int>
+    {
+        const RoutingGraph &graph = network->graph;
+        vector<unsigned> results(targets.size(), RoutingKit::inf_weight);
+        vector<unsigned> geo_distances(targets.size(), RoutingKit::inf_weight);
+        vector<unsigned> travel_times(targets.size(), RoutingKit::inf_weight);
+        vector<int> statuses(targets.size(), status_no_path);
+
+        // the pinned targets of target t start at first_pinned[t]
+        vector<SnappedPoint> snapped_targets;
         vector<unsigned> target_list;
-        vector<int> invalid_ids;
-        for (int i = 0; i < targets.size(); i++)
+        vector<unsigned> first_pinned;
+        for (unsigned t = 0; t < targets.size(); t++)
         {
-            auto target = targets[i];
-            unsigned to = map.find_nearest_neighbor_within_radius(target.lat, target.lon, radius).id;
-            if (to == invalid_id)
-            {
-                invalid_ids.push_back(i);
-            }
+            if (target_headings)
+                snapped_targets.push_back(snap_point(radius, targets[t].lon, targets[t].lat, target_bearings[t], target_tolerances[t]));
             else
-            {
-                target_list.push_back(to);
-            }
+                snapped_targets.push_back(snap_point(radius, targets[t].lon, targets[t].lat));
+            first_pinned.push_back(target_list.size());
+            for (auto offset : snapped_targets.back().targets)
+                target_list.push_back(offset.node);
         }
+        first_pinned.push_back(target_list.size());
 
         queries[i].reset().pin_targets(target_list);
 
-        unsigned from = map.find_nearest_neighbor_within_radius(source.lat, source.lon, radius).id;
-        if (from == invalid_id)
+        SnappedPoint from = snap_point(radius, source.lon, source.lat, source_bearing, source_tolerance);
+        bool include_metrics_ = response != nullptr && include_metrics;
+
+        if (!from.snapped)
+        {
+            fill(statuses.begin(), statuses.end(), status_source_not_snapped);
+        }
+        else
         {
-            for (int i = 0; i < targets.size(); i++)
+            for (auto offset : from.sources)
             {
-                results[i] = RoutingKit::inf_weight;
+                vector<unsigned> distances = queries[i].reset_source().add_source(offset.node).run_to_pinned_targets().get_distances_to_targets();
+                vector<unsigned> geo, time;
+                if (include_metrics_)
+                {
+                    geo = queries[i].get_extra_weight_distances_to_targets(geo_distance, SaturatedWeightAddition());
+                    time = queries[i].get_extra_weight_distances_to_targets(travel_time, SaturatedWeightAddition());
+                }
+                for (unsigned t = 0; t < targets.size(); t++)
+                {
+                    for (unsigned k = first_pinned[t]; k < first_pinned[t + 1]; k++)
+                    {
+                        const Offset &to = snapped_targets[t].targets[k - first_pinned[t]];
+                        unsigned distance = total(offset.weight, distances[k], to.weight);
+                        if (distance >= results[t])
+                            continue;
+                        results[t] = distance;
+                        if (include_metrics_)
+                        {
+                            geo_distances[t] = total(offset.geo_distance, geo[k], to.geo_distance);
+                            travel_times[t] = total(offset.travel_time, time[k], to.travel_time);
+                        }
+                    }
+                }
             }
-            return results;
         }
-        vector<unsigned> distances = queries[i].reset_source().add_source(from).run_to_pinned_targets().get_distances_to_targets();
 
-        auto invalid_id = invalid_ids.begin();
-        auto distance = distances.begin();
-        for (int i = 0; i < targets.size(); i++)
+        for (unsigned t = 0; t < targets.size(); t++)
         {
-            if (invalid_id != invalid_ids.end() && i == *invalid_id)
+            if (!from.snapped)
+                continue;
+            if (!snapped_targets[t].snapped)
             {
-                results[i] = RoutingKit::inf_weight;
-                invalid_id++;
+                statuses[t] = status_target_not_snapped;
+                continue;
             }
-            else
+            Offset direct;
+            Placement start, end;
+            if (direct_path(graph, *weight, from, snapped_targets[t], direct, start, end) && direct.weight < results[t])
             {
-                results[i] = *distance;
-                distance++;
+                results[t] = direct.weight;
+                geo_distances[t] = direct.geo_distance;
+                travel_times[t] = direct.travel_time;
             }
+            if (results[t] != RoutingKit::inf_weight)
+                statuses[t] = status_ok;
+        }
+
+        if (response != nullptr)
+        {
+            response->statuses = statuses;
+        }
+        if (include_metrics_)
+        {
+            response->geo_distances = geo_distances;
+            response->travel_times = travel_times;
         }
         return results;
     };
@@ -530,35 +1341,1262 @@ std::vector<unsigned> Client::distances(int i, float radius, Point source, std::
     return result;
 }
 
-QueryResponse Client::query(int i, float radius, float from_longitude, float from_latitude, float to_longitude, float to_latitude, bool include_waypoints)
+QueryResponse Client::query(int i, float radius, float from_longitude, float from_latitude, float from_bearing, float from_tolerance,
+                            float to_longitude, float to_latitude, float to_bearing, float to_tolerance, bool include_waypoints,
+                            bool include_segments)
 {
-    auto query = [this, i, radius, from_longitude, from_latitude, to_longitude, to_latitude, include_waypoints]()
+    auto query = [this, i, radius, from_longitude, from_latitude, from_bearing, from_tolerance, to_longitude, to_latitude,
+                  to_bearing, to_tolerance, include_waypoints, include_segments]()
     {
-        unsigned from = map.find_nearest_neighbor_within_radius(from_latitude, from_longitude, radius).id;
-        unsigned to = map.find_nearest_neighbor_within_radius(to_latitude, to_longitude, radius).id;
+        SnappedPoint from = snap_point(radius, from_longitude, from_latitude, from_bearing, from_tolerance);
+        SnappedPoint to = snap_point(radius, to_longitude, to_latitude, to_bearing, to_tolerance);
+        return route(i, from, to, include_waypoints, include_segments);
+    };
 
-        QueryResponse response;
-        if (from == invalid_id || to == invalid_id)
-        {
-            response.distance = RoutingKit::inf_weight;
-            return response;
-        }
+    auto future = std::async(launch::deferred, query);
+    auto result = future.get();
+    return result;
+}
+
+QueryResponse Client::continue_query(int i, float radius, unsigned arc, float fraction, float to_longitude, float to_latitude, bool include_waypoints, bool include_segments)
+{
+    auto query = [this, i, radius, arc, fraction, to_longitude, to_latitude, include_waypoints, include_segments]()
+    {
+        SnappedPoint from = continue_from(arc, fraction);
+        SnappedPoint to = snap_point(radius, to_longitude, to_latitude);
+        return route(i, from, to, include_waypoints, include_segments);
+    };
+
+    auto future = std::async(launch::deferred, query);
+    auto result = future.get();
+    return result;
+}
+
+// continue_from returns the given position on the arc as a point from which
+// paths can only continue in the direction of the arc.
+SnappedPoint Client::continue_from(unsigned arc, float fraction) const
+{
+    const RoutingGraph &graph = network->graph;
+    SnappedPoint snapped;
+    if (arc >= graph.arc_count())
+        return snapped;
+    unsigned x = network->tail[arc], y = graph.head[arc];
+    snapped.snapped = true;
+    snapped.arc = arc;
+    snapped.point = position(arc, fraction);
+    if (fraction < 1)
+        snapped.placements.push_back(Placement{arc, fraction});
+    else
+        // at the head of the arc, any arc leaving it except the one leading
+        // back can be taken
+        for (unsigned a = graph.first_out[y]; a < graph.first_out[y + 1]; ++a)
+            if (graph.head[a] != x)
+                snapped.placements.push_back(Placement{a, 0});
+    add_offsets(snapped);
+    return snapped;
+}
 
-        queries[i].reset().add_source(from).add_target(to).run();
-        auto distance = queries[i].get_distance();
+// route finds the shortest path between the snapped points. If path_arcs is
+// not null, the arcs traversed completely by the path are stored in it.
+QueryResponse Client::route(int i, const SnappedPoint &from, const SnappedPoint &to, bool include_waypoints, bool include_segments, std::vector<unsigned> *path_arcs)
+{
+    QueryResponse response;
+    response.last_arc = invalid_id;
+    response.last_fraction = 0;
+    response.distance = RoutingKit::inf_weight;
+    response.geo_distance = RoutingKit::inf_weight;
+    response.travel_time = RoutingKit::inf_weight;
+    response.source = from.point;
+    response.target = to.point;
+    if (!from.snapped)
+    {
+        response.status = status_source_not_snapped;
+        return response;
+    }
+    if (!to.snapped)
+    {
+        response.status = status_target_not_snapped;
+        return response;
+    }
 
-        response.distance = distance;
+    queries[i].reset();
+    for (auto offset : from.sources)
+        queries[i].add_source(offset.node, offset.weight);
+    for (auto offset : to.targets)
+        queries[i].add_target(offset.node, offset.weight);
+    queries[i].run();
+    auto distance = queries[i].get_distance();
+
+    const RoutingGraph &graph = network->graph;
+    Offset direct;
+    Placement start, end;
+    if (direct_path(graph, *weight, from, to, direct, start, end) && direct.weight < distance)
+    {
+        response.status = status_ok;
+        response.distance = direct.weight;
+        response.geo_distance = direct.geo_distance;
+        response.travel_time = direct.travel_time;
+        response.last_arc = end.arc;
+        response.last_fraction = end.fraction;
         if (include_waypoints)
         {
-            auto path = queries[i].get_node_path();
-            for (auto x : path)
-                response.waypoints.push_back(point(x));
+            response.waypoints.push_back(from.point);
+            append_geometry(start.arc, start.fraction, end.fraction, response.waypoints);
+            response.waypoints.push_back(to.point);
         }
+        if (include_segments)
+            response.segments.push_back(segment(start.arc, start.fraction, end.fraction));
+        return response;
+    }
 
+    response.distance = distance;
+    if (distance == RoutingKit::inf_weight)
+    {
+        response.status = status_no_path;
         return response;
+    }
+    response.status = status_ok;
+
+    // with arc snapping or when continuing along an arc, the path starts and
+    // ends on the parts of the arcs the points are located on
+    unsigned used_source = queries[i].get_used_source();
+    unsigned used_target = queries[i].get_used_target();
+    const Placement *first = nullptr, *last = nullptr;
+    for (const auto &placement : from.placements)
+        if (graph.head[placement.arc] == used_source)
+        {
+            first = &placement;
+            break;
+        }
+    for (const auto &placement : to.placements)
+        if (network->tail[placement.arc] == used_target)
+        {
+            last = &placement;
+            break;
+        }
+    vector<unsigned> arcs = queries[i].get_arc_path();
+    if (path_arcs != nullptr)
+        *path_arcs = arcs;
+    if (last != nullptr)
+    {
+        response.last_arc = last->arc;
+        response.last_fraction = last->fraction;
+    }
+    else if (!arcs.empty() || first != nullptr)
+    {
+        response.last_arc = arcs.empty() ? first->arc : arcs.back();
+        response.last_fraction = 1;
+    }
+
+    // sum up both metrics over the partial arcs at the ends and the arcs of
+    // the unpacked path
+    response.geo_distance = 0;
+    response.travel_time = 0;
+    for (auto offset : from.sources)
+        if (offset.node == used_source)
+        {
+            response.geo_distance += offset.geo_distance;
+            response.travel_time += offset.travel_time;
+            break;
+        }
+    for (auto offset : to.targets)
+        if (offset.node == used_target)
+        {
+            response.geo_distance += offset.geo_distance;
+            response.travel_time += offset.travel_time;
+            break;
+        }
+    for (auto a : arcs)
+    {
+        response.geo_distance += graph.geo_distance[a];
+        response.travel_time += graph.travel_time[a];
+    }
+    if (include_waypoints)
+    {
+        if (first != nullptr)
+        {
+            response.waypoints.push_back(from.point);
+            append_geometry(first->arc, first->fraction, 1, response.waypoints);
+        }
+        response.waypoints.push_back(point(used_source));
+        for (auto a : arcs)
+        {
+            append_geometry(a, 0, 1, response.waypoints);
+            response.waypoints.push_back(point(graph.head[a]));
+        }
+        if (last != nullptr)
+        {
+            append_geometry(last->arc, 0, last->fraction, response.waypoints);
+            response.waypoints.push_back(to.point);
+        }
+    }
+    if (include_segments)
+    {
+        if (first != nullptr && first->fraction < 1)
+            response.segments.push_back(segment(first->arc, first->fraction, 1));
+        for (auto a : arcs)
+            response.segments.push_back(segment(a, 0, 1));
+        if (last != nullptr && last->fraction > 0)
+            response.segments.push_back(segment(last->arc, 0, last->fraction));
+    }
+
+    return response;
+}
+
+// via_candidates returns the junctions considered as via nodes of alternative
+// paths between the points, whose straight-line detour via the junction is at
+// most length meters. They are found with the arc grid, so only the arcs near
+// the points are scanned. cells is set to the cell of every candidate in a grid
+// whose cells are a 32nd of length wide, to keep one candidate per cell.
+std::vector<unsigned> Client::via_candidates(Point from, Point to, double length, std::vector<long long> &cells) const
+{
+    const RoutingGraph &graph = network->graph;
+    const double meters_per_degree = M_PI / 180 * earth_radius;
+    double cell_size = length / 32;
+    vector<unsigned> candidates;
+    cells.clear();
+    if (cell_size <= 0)
+        return candidates;
+
+    // by the triangle inequality, every position whose detour is at most
+    // length lies within half of length plus the distances from the midpoint
+    // to both points of the midpoint
+    network->build_arc_grid();
+    float mid_lat = (from.lat + to.lat) / 2, mid_lon = (from.lon + to.lon) / 2;
+    double radius = (geo_dist(mid_lat, mid_lon, from.lat, from.lon) + geo_dist(mid_lat, mid_lon, to.lat, to.lon) + length) / 2;
+    double scale = cos(from.lat * M_PI / 180);
+    unordered_set<unsigned> seen;
+    for (unsigned a : network->arcs_near(mid_lat, mid_lon, radius))
+        for (unsigned node : {network->tail[a], graph.head[a]})
+        {
+            if (!seen.insert(node).second)
+                continue;
+            // via nodes between junctions lead to the same paths as the
+            // junctions
+            if (graph.first_out[node + 1] - graph.first_out[node] < 3)
+                continue;
+            float lat = graph.latitude[node], lon = graph.longitude[node];
+            if (geo_dist(from.lat, from.lon, lat, lon) + geo_dist(lat, lon, to.lat, to.lon) > length)
+                continue;
+            long long row = floor((lat - from.lat) * meters_per_degree / cell_size);
+            long long column = floor((lon - from.lon) * scale * meters_per_degree / cell_size);
+            candidates.push_back(node);
+            cells.push_back(arc_grid_cell(row, column));
+        }
+    return candidates;
+}
+
+std::vector<QueryResponse> Client::alternatives(int i, float radius, float from_longitude, float from_latitude, float to_longitude, float to_latitude, unsigned k, float max_stretch, float max_overlap, bool include_waypoints, bool include_segments)
+{
+    auto alts = [this, i, radius, from_longitude, from_latitude, to_longitude, to_latitude, k, max_stretch, max_overlap, include_waypoints, include_segments]()
+    {
+        const RoutingGraph &graph = network->graph;
+        SnappedPoint from = snap_point(radius, from_longitude, from_latitude);
+        SnappedPoint to = snap_point(radius, to_longitude, to_latitude);
+        vector<QueryResponse> routes;
+        vector<vector<unsigned>> route_arcs(1);
+        routes.push_back(route(i, from, to, include_waypoints, include_segments, &route_arcs[0]));
+        if (k < 2 || routes[0].status != status_ok)
+            return routes;
+
+        // the via-node method: the shortest path via a node consists of the
+        // shortest paths from the source to the node and from the node to the
+        // target, so the costs of the paths via all candidates are found with
+        // two one-to-many searches
+        double limit = (double)routes[0].distance * max_stretch;
+        double length = max((double)routes[0].geo_distance, geo_dist(from.point.lat, from.point.lon, to.point.lat, to.point.lon)) * max_stretch;
+        vector<long long> cells;
+        vector<unsigned> candidates = via_candidates(from.point, to.point, length, cells);
+        if (candidates.empty())
+            return routes;
+        vector<unsigned> costs(candidates.size(), inf_weight), to_target(candidates.size(), inf_weight);
+        queries[i].reset().pin_targets(candidates);
+        for (auto offset : from.sources)
+        {
+            vector<unsigned> distances = queries[i].reset_source().add_source(offset.node).run_to_pinned_targets().get_distances_to_targets();
+            for (unsigned c = 0; c < candidates.size(); ++c)
+                costs[c] = min(costs[c], total(offset.weight, distances[c], 0));
+        }
+        queries[i].reset().pin_sources(candidates);
+        for (auto offset : to.targets)
+        {
+            vector<unsigned> distances = queries[i].reset_target().add_target(offset.node).run_to_pinned_sources().get_distances_to_sources();
+            for (unsigned c = 0; c < candidates.size(); ++c)
+                to_target[c] = min(to_target[c], total(offset.weight, distances[c], 0));
+        }
+        // to bound the number of paths tried, only the candidate with the
+        // lowest cost via it is kept in each cell, preferring the lower node ID
+        // on ties
+        auto better = [&](unsigned a, unsigned b)
+        {
+            return costs[a] < costs[b] || (costs[a] == costs[b] && candidates[a] < candidates[b]);
+        };
+        unordered_map<long long, unsigned> best;
+        for (unsigned c = 0; c < candidates.size(); ++c)
+        {
+            costs[c] = total(costs[c], to_target[c], 0);
+            if (costs[c] == inf_weight || costs[c] > limit)
+                continue;
+            auto cell = best.find(cells[c]);
+            if (cell == best.end())
+                best[cells[c]] = c;
+            else if (better(c, cell->second))
+                cell->second = c;
+        }
+        vector<unsigned> order;
+        for (const auto &cell : best)
+            order.push_back(cell.second);
+        sort(order.begin(), order.end(), better);
+
+        // via nodes on a path found before lead to the same path again
+        vector<bool> visited(graph.node_count(), false);
+        auto visit = [&](const vector<unsigned> &arcs)
+        {
+            for (auto a : arcs)
+                visited[network->tail[a]] = visited[graph.head[a]] = true;
+        };
+        visit(route_arcs[0]);
+        vector<bool> on_path(graph.node_count(), false);
+        for (auto c : order)
+        {
+            if (routes.size() >= k)
+                break;
+            unsigned node = candidates[c];
+            if (visited[node])
+                continue;
+            SnappedPoint via;
+            via.snapped = true;
+            via.node = node;
+            via.point = point(node);
+            via.sources.push_back(Offset{node, 0, 0, 0});
+            via.targets.push_back(Offset{node, 0, 0, 0});
+            vector<unsigned> arcs, second_arcs;
+            QueryResponse first = route(i, from, via, include_waypoints, include_segments, &arcs);
+            QueryResponse second = route(i, via, to, include_waypoints, include_segments, &second_arcs);
+            if (first.status != status_ok || second.status != status_ok || total(first.distance, second.distance, 0) > limit)
+                continue;
+            arcs.insert(arcs.end(), second_arcs.begin(), second_arcs.end());
+            if (arcs.empty())
+                continue;
+
+            // reject paths that visit a node twice, e.g. by turning around at
+            // the via node
+            bool loop = false;
+            on_path[network->tail[arcs[0]]] = true;
+            for (auto a : arcs)
+            {
+                if (on_path[graph.head[a]])
+                    loop = true;
+                on_path[graph.head[a]] = true;
+            }
+            on_path[network->tail[arcs[0]]] = false;
+            for (auto a : arcs)
+                on_path[graph.head[a]] = false;
+            if (loop)
+                continue;
+
+            unsigned long long path_length = 0;
+            for (auto a : arcs)
+                path_length += graph.geo_distance[a];
+            bool overlapping = path_length == 0;
+            for (const auto &other : route_arcs)
+            {
+                unordered_set<unsigned> shared(other.begin(), other.end());
+                unsigned long long overlap = 0;
+                for (auto a : arcs)
+                    if (shared.count(a))
+                        overlap += graph.geo_distance[a];
+                overlapping = overlapping || overlap > max_overlap * path_length;
+            }
+            if (overlapping)
+                continue;
+
+            QueryResponse response = first;
+            response.distance = total(first.distance, second.distance, 0);
+            response.geo_distance = total(first.geo_distance, second.geo_distance, 0);
+            response.travel_time = total(first.travel_time, second.travel_time, 0);
+            response.target = second.target;
+            auto waypoint = second.waypoints.begin();
+            if (waypoint != second.waypoints.end() && !response.waypoints.empty())
+                ++waypoint;
+            response.waypoints.insert(response.waypoints.end(), waypoint, second.waypoints.end());
+            response.segments.insert(response.segments.end(), second.segments.begin(), second.segments.end());
+            if (second.last_arc != invalid_id)
+            {
+                response.last_arc = second.last_arc;
+                response.last_fraction = second.last_fraction;
+            }
+            routes.push_back(response);
+            route_arcs.push_back(arcs);
+            visit(arcs);
+        }
+        stable_sort(routes.begin() + 1, routes.end(), [](const QueryResponse &a, const QueryResponse &b)
+                    { return a.distance < b.distance; });
+        return routes;
     };
 
-    auto future = std::async(launch::deferred, query);
+    auto future = async(launch::deferred, alts);
     auto result = future.get();
     return result;
 }
+
+namespace
+{
+    // UpwardSearch runs Dijkstra's algorithm on the upward arcs of one side of
+    // a contraction hierarchy, pruned by stall-on-demand, and sums up both
+    // metrics along the search tree.
+    class UpwardSearch
+    {
+        MinIDQueue queue;
+        vector<unsigned> distance, geo_distance, travel_time;
+        vector<unsigned> reached;
+
+    public:
+        explicit UpwardSearch(unsigned node_count)
+            : queue(node_count), distance(node_count, inf_weight), geo_distance(node_count), travel_time(node_count) {}
+
+        // run starts the search at the offsets and calls settled for every
+        // node, given by its rank, whose distance is final and at most bound.
+        // The search stops early once settled returns false.
+        template <class Settled>
+        void run(const vector<Offset> &offsets, const ContractionHierarchy &ch, const ContractionHierarchy::Side &side,
+                 const ContractionHierarchy::Side &opposite, const vector<unsigned> &side_geo_distance,
+                 const vector<unsigned> &side_travel_time, unsigned bound, const Settled &settled)
+        {
+            for (auto x : reached)
+                distance[x] = inf_weight;
+            reached.clear();
+            queue.clear();
+            auto relax = [&](unsigned x, unsigned d, unsigned geo, unsigned time)
+            {
+                if (d > bound || d >= distance[x])
+                    return;
+                if (distance[x] == inf_weight)
+                {
+                    reached.push_back(x);
+                    queue.push({x, d});
+                }
+                else
+                    queue.decrease_key({x, d});
+                distance[x] = d;
+                geo_distance[x] = geo;
+                travel_time[x] = time;
+            };
+            for (auto offset : offsets)
+                relax(ch.rank[offset.node], offset.weight, offset.geo_distance, offset.travel_time);
+            while (!queue.empty())
+            {
+                unsigned x = queue.pop().id;
+                // x is not reached on a shortest path if a node above it,
+                // reached already, leads to it at lower cost
+                bool stalled = false;
+                for (unsigned a = opposite.first_out[x]; a < opposite.first_out[x + 1] && !stalled; ++a)
+                    stalled = total(distance[opposite.head[a]], opposite.weight[a], 0) < distance[x];
+                if (stalled)
+                    continue;
+                if (!settled(x, distance[x], geo_distance[x], travel_time[x]))
+                    return;
+                for (unsigned a = side.first_out[x]; a < side.first_out[x + 1]; ++a)
+                    relax(side.head[a], total(distance[x], side.weight[a], 0),
+                          total(geo_distance[x], side_geo_distance[a], 0), total(travel_time[x], side_travel_time[a], 0));
+            }
+        }
+    };
+
+    // BucketEntry is the distance from a node to a target found by the
+    // backward search from the target.
+    struct BucketEntry
+    {
+        unsigned target;
+        unsigned weight;
+        unsigned geo_distance;
+        unsigned travel_time;
+    };
+
+    // parallel_for calls f(thread, i) for i from 0 to n - 1 on up to the given
+    // number of threads, numbered from 0. Once cancelled, if it is not null,
+    // points to a value other than zero, no more calls are started. It returns
+    // the number of calls made, which were made for the lowest values of i.
+    template <class F>
+    unsigned parallel_for(unsigned n, unsigned threads, const F &f, const int *cancelled = nullptr)
+    {
+        threads = max(1u, min(threads, n));
+        atomic<unsigned> next(0);
+        auto work = [&](unsigned thread)
+        {
+            while (cancelled == nullptr || __atomic_load_n(cancelled, __ATOMIC_RELAXED) == 0)
+            {
+                unsigned i = next++;
+                if (i >= n)
+                    return;
+                f(thread, i);
+            }
+        };
+        vector<std::thread> workers;
+        for (unsigned t = 1; t < threads; ++t)
+            workers.emplace_back(work, t);
+        work(0);
+        for (auto &w : workers)
+            w.join();
+        return min(next.load(), n);
+    }
+}
+
+// Search holds the state of the searches run with a query slot, so that it is
+// allocated once per slot rather than once per search.
+struct GoRoutingKit::Client::Search
+{
+    UpwardSearch upward;
+    // cells holds the cost of every target from the source of the row being
+    // computed, and reached_targets the targets whose cost is not inf_weight,
+    // so that only those have to be reset after the row.
+    vector<unsigned> cells;
+    vector<unsigned> reached_targets;
+    // distance and queue hold the state of the searches of one_to_all and
+    // reachable, and reached the nodes whose distance is not inf_weight, so
+    // that only those have to be reset before the next search. They are
+    // allocated by the first such search.
+    vector<unsigned> distance;
+    MinIDQueue queue;
+    vector<unsigned> reached;
+
+    explicit Search(unsigned node_count) : upward(node_count) {}
+
+    // reset prepares distance and queue for a search on node_count nodes.
+    void reset(unsigned node_count)
+    {
+        if (distance.empty())
+        {
+            distance.assign(node_count, inf_weight);
+            queue = MinIDQueue(node_count);
+        }
+        for (auto x : reached)
+            distance[x] = inf_weight;
+        reached.clear();
+        queue.clear();
+    }
+};
+
+Client::Search &Client::search(int i)
+{
+    if (searches[i] == nullptr)
+        searches[i] = make_shared<Search>(ch.node_count());
+    return *searches[i];
+}
+
+struct GoRoutingKit::TargetBuckets::Data
+{
+    vector<SnappedPoint> targets;
+    // the entries of the bucket of the node with rank x are found at
+    // positions first_entry[x] to first_entry[x+1]-1
+    vector<unsigned> first_entry;
+    vector<BucketEntry> entries;
+    // targets on each arc, for paths that do not leave the arc
+    unordered_map<unsigned, vector<unsigned>> targets_on_arc;
+};
+
+unsigned TargetBuckets::target_count() const
+{
+    return data == nullptr ? 0 : data->targets.size();
+}
+
+TargetBuckets Client::target_buckets(std::vector<int> slots, float radius, std::vector<Point> targets, unsigned bound)
+{
+    unsigned target_count = targets.size();
+    auto data = make_shared<TargetBuckets::Data>();
+
+    data->targets.resize(target_count);
+    parallel_for(target_count, slots.size(), [&](unsigned, unsigned t)
+                 { data->targets[t] = snap_point(radius, targets[t].lon, targets[t].lat); });
+
+    // the backward search from every target stores its distance to each
+    // settled node in the bucket of the node
+    vector<vector<pair<unsigned, BucketEntry>>> entries(slots.size());
+    parallel_for(target_count, slots.size(), [&](unsigned thread, unsigned t)
+                 { search(slots[thread]).upward.run(data->targets[t].targets, ch, ch.backward, ch.forward, geo_distance.backward_weight, travel_time.backward_weight, bound,
+                                        [&](unsigned x, unsigned d, unsigned geo, unsigned time)
+                                        { entries[thread].push_back({x, BucketEntry{t, d, geo, time}});
+                                          return true; }); });
+    data->first_entry.assign(ch.node_count() + 1, 0);
+    for (const auto &thread_entries : entries)
+        for (const auto &e : thread_entries)
+            ++data->first_entry[e.first + 1];
+    partial_sum(data->first_entry.begin(), data->first_entry.end(), data->first_entry.begin());
+    data->entries.resize(data->first_entry.back());
+    {
+        vector<unsigned> next(data->first_entry.begin(), data->first_entry.end() - 1);
+        for (auto &thread_entries : entries)
+        {
+            for (const auto &e : thread_entries)
+                data->entries[next[e.first]++] = e.second;
+            vector<pair<unsigned, BucketEntry>>().swap(thread_entries);
+        }
+    }
+
+    if (snap_to_arcs)
+        for (unsigned t = 0; t < target_count; ++t)
+            for (const auto &placement : data->targets[t].placements)
+                data->targets_on_arc[placement.arc].push_back(t);
+
+    TargetBuckets buckets;
+    buckets.data = data;
+    return buckets;
+}
+
+unsigned Client::many_to_many_rows(std::vector<int> slots, const TargetBuckets &buckets, float radius, std::vector<Point> sources, unsigned *distances, int *statuses, unsigned *geo_distances, unsigned *travel_times, const int *cancelled)
+{
+    if (buckets.data == nullptr)
+        return 0;
+    const TargetBuckets::Data &data = *buckets.data;
+    const RoutingGraph &graph = network->graph;
+    unsigned source_count = sources.size(), target_count = data.targets.size();
+
+    // the forward search from every source scans the buckets of the settled
+    // nodes
+    return parallel_for(source_count, slots.size(), [&](unsigned thread, unsigned s)
+                        {
+        unsigned long long row = (unsigned long long)s * target_count;
+        unsigned *cells = distances + row;
+        fill(cells, cells + target_count, inf_weight);
+        if (geo_distances != nullptr)
+            fill(geo_distances + row, geo_distances + row + target_count, inf_weight);
+        if (travel_times != nullptr)
+            fill(travel_times + row, travel_times + row + target_count, inf_weight);
+        SnappedPoint from = snap_point(radius, sources[s].lon, sources[s].lat);
+        if (from.snapped)
+            search(slots[thread]).upward.run(from.sources, ch, ch.forward, ch.backward, geo_distance.forward_weight, travel_time.forward_weight, inf_weight,
+                                 [&](unsigned x, unsigned d, unsigned geo, unsigned time)
+                                 {
+                for (unsigned b = data.first_entry[x]; b < data.first_entry[x + 1]; ++b)
+                {
+                    const BucketEntry &e = data.entries[b];
+                    unsigned distance = total(d, e.weight, 0);
+                    if (distance >= cells[e.target])
+                        continue;
+                    cells[e.target] = distance;
+                    if (geo_distances != nullptr)
+                        geo_distances[row + e.target] = total(geo, e.geo_distance, 0);
+                    if (travel_times != nullptr)
+                        travel_times[row + e.target] = total(time, e.travel_time, 0);
+                }
+                return true; });
+        if (from.snapped && snap_to_arcs)
+            for (const auto &placement : from.placements)
+            {
+                auto on_arc = data.targets_on_arc.find(placement.arc);
+                if (on_arc == data.targets_on_arc.end())
+                    continue;
+                for (auto t : on_arc->second)
+                {
+                    Offset direct;
+                    Placement start, end;
+                    if (!direct_path(graph, *weight, from, data.targets[t], direct, start, end) || direct.weight >= cells[t])
+                        continue;
+                    cells[t] = direct.weight;
+                    if (geo_distances != nullptr)
+                        geo_distances[row + t] = direct.geo_distance;
+                    if (travel_times != nullptr)
+                        travel_times[row + t] = direct.travel_time;
+                }
+            }
+        if (statuses != nullptr)
+            for (unsigned t = 0; t < target_count; ++t)
+            {
+                if (!from.snapped)
+                    statuses[row + t] = status_source_not_snapped;
+                else if (!data.targets[t].snapped)
+                    statuses[row + t] = status_target_not_snapped;
+                else if (cells[t] == inf_weight)
+                    statuses[row + t] = status_no_path;
+                else
+                    statuses[row + t] = status_ok;
+            } }, cancelled);
+}
+
+unsigned Client::many_to_many(std::vector<int> slots, float radius, std::vector<Point> sources, std::vector<Point> targets, unsigned *distances, int *statuses, unsigned *geo_distances, unsigned *travel_times, const int *cancelled)
+{
+    return many_to_many_rows(slots, target_buckets(slots, radius, targets, inf_weight), radius, sources, distances, statuses, geo_distances, travel_times, cancelled);
+}
+
+std::vector<unsigned> Client::sparse_many_to_many(std::vector<int> slots, const TargetBuckets &buckets, float radius, std::vector<Point> sources, unsigned bound, const int *cancelled)
+{
+    if (buckets.data == nullptr)
+        return {};
+    const TargetBuckets::Data &data = *buckets.data;
+    const RoutingGraph &graph = network->graph;
+    unsigned source_count = sources.size(), target_count = data.targets.size();
+
+    // rows holds the targets and costs of the cells within the bound of every
+    // source
+    vector<vector<pair<unsigned, unsigned>>> rows(source_count);
+    parallel_for(source_count, slots.size(), [&](unsigned thread, unsigned s)
+                 {
+        // the search keeps the cells of the row it computes, and resets the
+        // ones it reached afterwards
+        Search &state = search(slots[thread]);
+        if (state.cells.size() < target_count)
+            state.cells.resize(target_count, inf_weight);
+        vector<unsigned> &row = state.cells;
+        vector<unsigned> &targets = state.reached_targets;
+        auto improve = [&](unsigned t, unsigned distance)
+        {
+            if (distance > bound || distance >= row[t])
+                return;
+            if (row[t] == inf_weight)
+                targets.push_back(t);
+            row[t] = distance;
+        };
+        SnappedPoint from = snap_point(radius, sources[s].lon, sources[s].lat);
+        if (!from.snapped)
+            return;
+        state.upward.run(from.sources, ch, ch.forward, ch.backward, geo_distance.forward_weight, travel_time.forward_weight, bound,
+                             [&](unsigned x, unsigned d, unsigned, unsigned)
+                             {
+            for (unsigned b = data.first_entry[x]; b < data.first_entry[x + 1]; ++b)
+                improve(data.entries[b].target, total(d, data.entries[b].weight, 0));
+            return true;
+        });
+        if (snap_to_arcs)
+            for (const auto &placement : from.placements)
+            {
+                auto on_arc = data.targets_on_arc.find(placement.arc);
+                if (on_arc == data.targets_on_arc.end())
+                    continue;
+                for (auto t : on_arc->second)
+                {
+                    Offset direct;
+                    Placement start, end;
+                    if (direct_path(graph, *weight, from, data.targets[t], direct, start, end))
+                        improve(t, direct.weight);
+                }
+            }
+        sort(targets.begin(), targets.end());
+        for (auto t : targets)
+        {
+            rows[s].push_back({t, row[t]});
+            row[t] = inf_weight;
+        }
+        targets.clear(); }, cancelled);
+
+    vector<unsigned> cells_within;
+    for (unsigned s = 0; s < source_count; ++s)
+        for (const auto &cell : rows[s])
+        {
+            cells_within.push_back(s);
+            cells_within.push_back(cell.first);
+            cells_within.push_back(cell.second);
+        }
+    return cells_within;
+}
+
+std::vector<unsigned> Client::nearest_targets(int i, const TargetBuckets &buckets, float radius, Point source, unsigned k, unsigned bound)
+{
+    if (buckets.data == nullptr || k == 0)
+        return {};
+    const TargetBuckets::Data &data = *buckets.data;
+    const RoutingGraph &graph = network->graph;
+    unsigned target_count = data.targets.size();
+    SnappedPoint from = snap_point(radius, source.lon, source.lat);
+    if (!from.snapped)
+        return {};
+
+    Search &state = search(i);
+    if (state.cells.size() < target_count)
+        state.cells.resize(target_count, inf_weight);
+    vector<unsigned> &row = state.cells;
+    vector<unsigned> &targets = state.reached_targets;
+    // largest holds the costs at which the first k targets were reached, with
+    // the largest on top: the kth lowest cost of any target is at most the top
+    // once it holds k costs, as costs only decrease after a target is reached
+    priority_queue<unsigned> largest;
+    auto improve = [&](unsigned t, unsigned distance)
+    {
+        if (distance > bound || distance >= row[t])
+            return;
+        if (row[t] == inf_weight)
+        {
+            targets.push_back(t);
+            largest.push(distance);
+            if (largest.size() > k)
+                largest.pop();
+        }
+        row[t] = distance;
+    };
+    if (snap_to_arcs)
+        for (const auto &placement : from.placements)
+        {
+            auto on_arc = data.targets_on_arc.find(placement.arc);
+            if (on_arc == data.targets_on_arc.end())
+                continue;
+            for (auto t : on_arc->second)
+            {
+                Offset direct;
+                Placement start, end;
+                if (direct_path(graph, *weight, from, data.targets[t], direct, start, end))
+                    improve(t, direct.weight);
+            }
+        }
+    // every target reached through a node settled at distance d costs at
+    // least d, so the search stops once d exceeds the kth lowest cost; equal
+    // costs are still scanned, as a target with a lower index may come first
+    state.upward.run(from.sources, ch, ch.forward, ch.backward, geo_distance.forward_weight, travel_time.forward_weight, bound,
+                     [&](unsigned x, unsigned d, unsigned, unsigned)
+                     {
+        if (largest.size() == k && d > largest.top())
+            return false;
+        for (unsigned b = data.first_entry[x]; b < data.first_entry[x + 1]; ++b)
+            improve(data.entries[b].target, total(d, data.entries[b].weight, 0));
+        return true; });
+
+    sort(targets.begin(), targets.end(), [&](unsigned a, unsigned b)
+         { return row[a] < row[b] || (row[a] == row[b] && a < b); });
+    vector<unsigned> nearest;
+    for (unsigned j = 0; j < targets.size() && j < k; ++j)
+    {
+        nearest.push_back(targets[j]);
+        nearest.push_back(row[targets[j]]);
+    }
+    for (auto t : targets)
+        row[t] = inf_weight;
+    targets.clear();
+    return nearest;
+}
+
+unsigned Client::node_count() const
+{
+    return network->graph.node_count();
+}
+
+void Client::node_positions(float *longitudes, float *latitudes) const
+{
+    const RoutingGraph &graph = network->graph;
+    copy(graph.longitude.begin(), graph.longitude.end(), longitudes);
+    copy(graph.latitude.begin(), graph.latitude.end(), latitudes);
+}
+
+bool Client::one_to_all(int i, float radius, float longitude, float latitude, unsigned *costs, const int *cancelled)
+{
+    unsigned node_count = ch.node_count();
+    fill(costs, costs + node_count, inf_weight);
+    SnappedPoint from = snap_point(radius, longitude, latitude);
+    if (!from.snapped)
+        return false;
+    auto is_cancelled = [&]
+    { return cancelled != nullptr && __atomic_load_n(cancelled, __ATOMIC_RELAXED) != 0; };
+
+    // the upward search must not stall nodes, because the sweep relies on
+    // the distances of all nodes above the source being exact
+    Search &s = search(i);
+    s.reset(node_count);
+    vector<unsigned> &distance = s.distance;
+    auto relax = [&](unsigned x, unsigned d)
+    {
+        if (d >= distance[x])
+            return;
+        if (distance[x] == inf_weight)
+        {
+            s.reached.push_back(x);
+            s.queue.push({x, d});
+        }
+        else
+            s.queue.decrease_key({x, d});
+        distance[x] = d;
+    };
+    for (auto offset : from.sources)
+        relax(ch.rank[offset.node], offset.weight);
+    while (!s.queue.empty())
+    {
+        unsigned x = s.queue.pop().id;
+        for (unsigned a = ch.forward.first_out[x]; a < ch.forward.first_out[x + 1]; ++a)
+            relax(ch.forward.head[a], total(distance[x], ch.forward.weight[a], 0));
+    }
+    if (is_cancelled())
+        return true;
+
+    // the backward arcs of a node lead to higher ranked nodes, whose
+    // distances are final when the node is scanned
+    for (unsigned x = node_count; x-- > 0;)
+    {
+        if (x % 65536 == 0 && is_cancelled())
+            return true;
+        unsigned d = distance[x];
+        for (unsigned a = ch.backward.first_out[x]; a < ch.backward.first_out[x + 1]; ++a)
+            d = min(d, total(distance[ch.backward.head[a]], ch.backward.weight[a], 0));
+        if (d == distance[x])
+            continue;
+        if (distance[x] == inf_weight)
+            s.reached.push_back(x);
+        distance[x] = d;
+    }
+
+    for (unsigned node = 0; node < node_count; ++node)
+        costs[node] = distance[ch.rank[node]];
+    return true;
+}
+
+void Client::sample_costs(float radius, float longitude, float latitude, const unsigned *costs, std::vector<Point> points, unsigned *sampled) const
+{
+    const RoutingGraph &graph = network->graph;
+    SnappedPoint from = snap_point(radius, longitude, latitude);
+    for (unsigned p = 0; p < points.size(); ++p)
+    {
+        sampled[p] = inf_weight;
+        SnappedPoint to = snap_point(radius, points[p].lon, points[p].lat);
+        if (!from.snapped || !to.snapped)
+            continue;
+        for (auto offset : to.targets)
+            sampled[p] = min(sampled[p], total(costs[offset.node], offset.weight, 0));
+        Offset direct;
+        Placement start, end;
+        if (snap_to_arcs && direct_path(graph, *weight, from, to, direct, start, end))
+            sampled[p] = min(sampled[p], direct.weight);
+    }
+}
+
+// sample_arc appends positions on the arc between the given positions on it to
+// the response, at most spacing meters apart, together with their costs, which
+// are interpolated between the costs at from and to.
+void Client::sample_arc(unsigned arc, double from, double to, unsigned from_cost, unsigned to_cost, float spacing, ReachResponse &response) const
+{
+    // the corners of the arc's geometry and their positions along the arc
+    vector<Point> points;
+    vector<double> fractions;
+    network->polyline(arc, points);
+    polyline_fractions(points, fractions);
+    vector<pair<double, Point>> corners{{from, position(arc, from)}};
+    for (unsigned i = 1; i + 1 < points.size(); ++i)
+        if (fractions[i] > from && fractions[i] < to)
+            corners.push_back({fractions[i], points[i]});
+    corners.push_back({to, position(arc, to)});
+
+    for (unsigned c = 1; c < corners.size(); ++c)
+    {
+        const auto &a = corners[c - 1], &b = corners[c];
+        double length = geo_dist(a.second.lat, a.second.lon, b.second.lat, b.second.lon);
+        unsigned steps = max(1u, (unsigned)ceil(length / spacing));
+        for (unsigned k = 1; k <= steps; ++k)
+        {
+            double t = (double)k / steps;
+            double fraction = a.first + t * (b.first - a.first);
+            double along = to > from ? (fraction - from) / (to - from) : 1;
+            response.points.push_back(Point{
+                (float)(a.second.lon + t * (b.second.lon - a.second.lon)),
+                (float)(a.second.lat + t * (b.second.lat - a.second.lat))});
+            response.costs.push_back((unsigned)llround(from_cost + along * ((double)to_cost - from_cost)));
+        }
+    }
+}
+
+ReachResponse Client::reachable(int i, float radius, float longitude, float latitude, unsigned limit, float spacing, bool reverse,
+                                const int *cancelled)
+{
+    const RoutingGraph &graph = network->graph;
+    ReachResponse response;
+    SnappedPoint center = snap_point(radius, longitude, latitude);
+    if (!center.snapped)
+        return response;
+    response.points.push_back(center.point);
+    response.costs.push_back(0);
+
+    // sample samples the part of the arc reachable within the limit from the
+    // position on it at the given cost, towards its head or, in reverse,
+    // towards its tail
+    auto sample = [&](unsigned arc, double fraction, unsigned cost)
+    {
+        double arc_weight = (*weight)[arc];
+        double length = arc_weight == 0 ? 1 : (double)(limit - cost) / arc_weight;
+        if (!reverse)
+        {
+            double to = min(1.0, fraction + length);
+            sample_arc(arc, fraction, to, cost, (unsigned)llround(cost + (to - fraction) * arc_weight), spacing, response);
+        }
+        else
+        {
+            double from = max(0.0, fraction - length);
+            sample_arc(arc, from, fraction, (unsigned)llround(cost + (fraction - from) * arc_weight), cost, spacing, response);
+        }
+    };
+    // the parts of the arcs of a point snapped to arcs up to their ends
+    for (const auto &placement : center.placements)
+        sample(placement.arc, placement.fraction, 0);
+
+    Search &s = search(i);
+    s.reset(graph.node_count());
+    vector<unsigned> &distance = s.distance;
+    auto relax = [&](unsigned x, unsigned d)
+    {
+        if (d > limit || d >= distance[x])
+            return;
+        if (distance[x] == inf_weight)
+        {
+            s.reached.push_back(x);
+            s.queue.push({x, d});
+        }
+        else
+            s.queue.decrease_key({x, d});
+        distance[x] = d;
+    };
+    for (auto offset : reverse ? center.targets : center.sources)
+        relax(offset.node, offset.weight);
+    for (unsigned scanned = 0; !s.queue.empty(); ++scanned)
+    {
+        if (scanned % 65536 == 0 && cancelled != nullptr && __atomic_load_n(cancelled, __ATOMIC_RELAXED) != 0)
+            break;
+        unsigned x = s.queue.pop().id;
+        response.points.push_back(point(x));
+        response.costs.push_back(distance[x]);
+        if (!reverse)
+            for (unsigned a = graph.first_out[x]; a < graph.first_out[x + 1]; ++a)
+            {
+                relax(graph.head[a], total(distance[x], (*weight)[a], 0));
+                sample(a, 0, distance[x]);
+            }
+        else
+            for (unsigned i = network->first_in[x]; i < network->first_in[x + 1]; ++i)
+            {
+                unsigned a = network->in_arc[i];
+                relax(network->tail[a], total(distance[x], (*weight)[a], 0));
+                sample(a, 1, distance[x]);
+            }
+    }
+    return response;
+}
+
+MatchResponse Client::map_match(int i, float radius, std::vector<Point> trace, std::vector<float> seconds, float sigma,
+                                float beta, float max_speed, unsigned max_candidates)
+{
+    const RoutingGraph &graph = network->graph;
+    unsigned n = trace.size();
+    MatchResponse response;
+    response.points.assign(n, Point{0, 0});
+    response.matched.assign(n, 0);
+    response.confidences.assign(n, 0);
+    response.distance = 0;
+    network->build_arc_grid();
+
+    // the candidates of every position are its projections onto the nearest
+    // arcs, one per direction of a road
+    struct Candidate
+    {
+        SnappedPoint snapped;
+        double distance;
+    };
+    vector<vector<Candidate>> candidates(n);
+    for (unsigned t = 0; t < n; ++t)
+    {
+        auto &near = candidates[t];
+        vector<Point> points;
+        for (unsigned a : network->arcs_near(trace[t].lat, trace[t].lon, radius))
+        {
+            network->polyline(a, points);
+            Projection p = project_polyline(trace[t].lat, trace[t].lon, points);
+            if (p.distance > radius)
+                continue;
+            Candidate candidate;
+            candidate.distance = p.distance;
+            candidate.snapped.snapped = true;
+            candidate.snapped.arc = a;
+            candidate.snapped.point = position(a, p.fraction);
+            candidate.snapped.placements.push_back(Placement{a, p.fraction});
+            near.push_back(candidate);
+        }
+        stable_sort(near.begin(), near.end(), [](const Candidate &a, const Candidate &b)
+                    { return a.distance < b.distance; });
+        if (near.size() > max_candidates)
+            near.resize(max_candidates);
+        for (auto &candidate : near)
+            add_offsets(candidate.snapped);
+    }
+
+    // positions without candidates are skipped, so before[t] is the last
+    // position before t with candidates, if any
+    vector<unsigned> before(n, invalid_id);
+    for (unsigned t = 1; t < n; ++t)
+        before[t] = candidates[t - 1].empty() ? before[t - 1] : t - 1;
+
+    // transition[t][j][k] is the log probability of moving from candidate j
+    // of position before[t] to candidate k of position t
+    const double impossible = -numeric_limits<double>::infinity();
+    vector<vector<vector<double>>> transition(n);
+    for (unsigned t = 1; t < n; ++t)
+    {
+        unsigned s = before[t];
+        if (s == invalid_id || candidates[t].empty())
+            continue;
+        const auto &from = candidates[s], &to = candidates[t];
+        double straight = geo_dist(trace[s].lat, trace[s].lon, trace[t].lat, trace[t].lon);
+        double elapsed = max(0.0, (double)seconds[t] - seconds[s]);
+
+        // the pinned targets of candidate k start at first_pinned[k]
+        vector<unsigned> target_list, first_pinned;
+        for (const auto &candidate : to)
+        {
+            first_pinned.push_back(target_list.size());
+            for (auto offset : candidate.snapped.targets)
+                target_list.push_back(offset.node);
+        }
+        first_pinned.push_back(target_list.size());
+        queries[i].reset().pin_targets(target_list);
+
+        transition[t].assign(from.size(), vector<double>(to.size(), impossible));
+        for (unsigned j = 0; j < from.size(); ++j)
+        {
+            // the length in meters of the path of lowest weight to every
+            // candidate
+            vector<unsigned> weights(to.size(), inf_weight), lengths(to.size(), inf_weight);
+            for (auto offset : from[j].snapped.sources)
+            {
+                vector<unsigned> distances = queries[i].reset_source().add_source(offset.node).run_to_pinned_targets().get_distances_to_targets();
+                vector<unsigned> geo = queries[i].get_extra_weight_distances_to_targets(geo_distance, SaturatedWeightAddition());
+                for (unsigned k = 0; k < to.size(); ++k)
+                    for (unsigned p = first_pinned[k]; p < first_pinned[k + 1]; ++p)
+                    {
+                        const Offset &target = to[k].snapped.targets[p - first_pinned[k]];
+                        unsigned w = total(offset.weight, distances[p], target.weight);
+                        if (w < weights[k])
+                        {
+                            weights[k] = w;
+                            lengths[k] = total(offset.geo_distance, geo[p], target.geo_distance);
+                        }
+                    }
+            }
+            for (unsigned k = 0; k < to.size(); ++k)
+            {
+                Offset direct;
+                Placement start, end;
+                if (direct_path(graph, *weight, from[j].snapped, to[k].snapped, direct, start, end) && direct.weight < weights[k])
+                    lengths[k] = direct.geo_distance;
+                // the measured positions may be off by the distances to
+                // the candidates
+                if (lengths[k] == inf_weight || lengths[k] > max_speed * elapsed + from[j].distance + to[k].distance)
+                    continue;
+                transition[t][j][k] = -fabs(lengths[k] - straight) / beta;
+            }
+        }
+    }
+
+    auto emission = [&](unsigned t, unsigned k)
+    {
+        double z = candidates[t][k].distance / sigma;
+        return -0.5 * z * z;
+    };
+    auto log_sum = [&](double a, double b)
+    {
+        if (a == impossible)
+            return b;
+        if (b == impossible)
+            return a;
+        double m = max(a, b);
+        return m + log(exp(a - m) + exp(b - m));
+    };
+
+    // forward holds the log probabilities of the trace up to each candidate,
+    // and best those of the most likely sequence of candidates ending at each
+    // candidate, which comes from candidate previous of the position before.
+    // A position that cannot be reached from the position before starts a new
+    // part of the trace.
+    vector<vector<double>> forward(n), best(n);
+    vector<vector<unsigned>> previous(n);
+    vector<bool> starts(n, true);
+    for (unsigned t = 0; t < n; ++t)
+    {
+        unsigned count = candidates[t].size();
+        forward[t].assign(count, impossible);
+        best[t].assign(count, impossible);
+        previous[t].assign(count, invalid_id);
+        if (!transition[t].empty())
+            for (unsigned j = 0; j < transition[t].size(); ++j)
+                for (unsigned k = 0; k < count; ++k)
+                {
+                    double p = transition[t][j][k];
+                    if (p == impossible || best[before[t]][j] == impossible)
+                        continue;
+                    forward[t][k] = log_sum(forward[t][k], forward[before[t]][j] + p);
+                    if (best[before[t]][j] + p > best[t][k])
+                    {
+                        best[t][k] = best[before[t]][j] + p;
+                        previous[t][k] = j;
+                    }
+                    starts[t] = false;
+                }
+        for (unsigned k = 0; k < count; ++k)
+        {
+            if (starts[t])
+                forward[t][k] = best[t][k] = 0;
+            forward[t][k] += emission(t, k);
+            best[t][k] += emission(t, k);
+        }
+    }
+
+    vector<unsigned> chosen(n, invalid_id);
+    auto append = [&](const Point &p)
+    {
+        if (response.path.empty() || response.path.back().lon != p.lon || response.path.back().lat != p.lat)
+            response.path.push_back(p);
+    };
+    // the parts of the trace, each given by its positions with candidates
+    vector<vector<unsigned>> parts;
+    for (unsigned t = 0; t < n; ++t)
+        if (!candidates[t].empty())
+        {
+            if (starts[t])
+                parts.emplace_back();
+            parts.back().push_back(t);
+        }
+    for (const auto &part : parts)
+    {
+        // the most likely sequence of candidates of the part
+        unsigned last = part.back();
+        chosen[last] = max_element(best[last].begin(), best[last].end()) - best[last].begin();
+        for (unsigned p = part.size() - 1; p > 0; --p)
+            chosen[part[p - 1]] = previous[part[p]][chosen[part[p]]];
+
+        // the probability of the chosen position given the whole part. The
+        // candidates within sigma meters of it, e.g. on the arcs of both
+        // directions of a road or at the node where arcs meet, cannot be told
+        // apart from it, so their probabilities are added up
+        vector<double> backward(candidates[last].size(), 0);
+        for (unsigned p = part.size(); p-- > 0;)
+        {
+            unsigned t = part[p];
+            if (p + 1 < part.size())
+            {
+                unsigned u = part[p + 1];
+                vector<double> earlier(candidates[t].size(), impossible);
+                for (unsigned j = 0; j < earlier.size(); ++j)
+                    for (unsigned k = 0; k < backward.size(); ++k)
+                        if (transition[u][j][k] != impossible)
+                            earlier[j] = log_sum(earlier[j], transition[u][j][k] + emission(u, k) + backward[k]);
+                backward.swap(earlier);
+            }
+            const Point &matched = candidates[t][chosen[t]].snapped.point;
+            double all = impossible, same = impossible;
+            for (unsigned k = 0; k < backward.size(); ++k)
+            {
+                all = log_sum(all, forward[t][k] + backward[k]);
+                const Point &other = candidates[t][k].snapped.point;
+                if (geo_dist(matched.lat, matched.lon, other.lat, other.lon) <= sigma)
+                    same = log_sum(same, forward[t][k] + backward[k]);
+            }
+            response.confidences[t] = min(1.0, exp(same - all));
+        }
+
+        append(candidates[part[0]][chosen[part[0]]].snapped.point);
+        for (unsigned p = 0; p < part.size(); ++p)
+        {
+            unsigned t = part[p];
+            response.points[t] = candidates[t][chosen[t]].snapped.point;
+            response.matched[t] = 1;
+            if (p == 0)
+                continue;
+            unsigned s = part[p - 1];
+            QueryResponse route = this->route(i, candidates[s][chosen[s]].snapped, candidates[t][chosen[t]].snapped, true, false);
+            if (route.status != status_ok)
+                continue;
+            response.distance += route.geo_distance;
+            for (const auto &waypoint : route.waypoints)
+                append(waypoint);
+        }
+    }
+    return response;
+}
@@ -2,6 +2,10 @@
 #define __MYCLASS_H
 #include <vector>
 #include <map>
+#include <string>
+#include <mutex>
+#include <memory>
+#include <unordered_map>
 #include <routingkit/osm_simple.h>
 #include <routingkit/contraction_hierarchy.h>
 #include <routingkit/geo_position_to_node.h>
@@ -12,10 +16,121 @@ struct Point
         float lat;
 };
 
+// query_status describes the outcome of a query between two points.
+enum query_status
+{
+        status_ok = 0,
+        status_source_not_snapped = 1,
+        status_target_not_snapped = 2,
+        status_no_path = 3
+};
+
+// Segment describes the part of a path along a single arc.
+struct Segment
+{
+        // way_id, name, ref and highway describe the OSM way of the arc. name,
+        // ref and highway are empty if the way has no such tags.
+        long long way_id;
+        const char *name;
+        const char *ref;
+        const char *highway;
+        // distance and duration are the length in meters and the travel time
+        // in milliseconds of the part of the arc, and speed is the speed in
+        // km/h used to compute the travel time.
+        unsigned distance;
+        unsigned duration;
+        unsigned speed;
+        // from and to are the start and end of the part of the arc.
+        Point from;
+        Point to;
+        // start_bearing and end_bearing are the directions of travel in
+        // degrees clockwise from north at from and to, taken from the shape of
+        // the arc next to them.
+        float start_bearing;
+        float end_bearing;
+        // roundabout is set if the way is part of a roundabout.
+        bool roundabout;
+        // branches is the number of arcs leaving the end of the part of the
+        // arc, other than the one leading back. It is zero if the part ends
+        // before the head of the arc.
+        unsigned branches;
+};
+
 struct QueryResponse
 {
+        query_status status;
         unsigned distance;
+        // geo_distance and travel_time are the length in meters and the
+        // travel time in milliseconds of the path that minimizes distance.
+        unsigned geo_distance;
+        unsigned travel_time;
         std::vector<Point> waypoints;
+        // source and target are the road network points the query points were
+        // snapped to. They are zero if a query point could not be snapped.
+        Point source;
+        Point target;
+        // segments describe the path arc by arc. They are only set if
+        // requested.
+        std::vector<Segment> segments;
+        // last_arc and last_fraction give the position on the arc at which
+        // the path ends, to continue it with continue_query. last_arc is
+        // invalid_arc if the path does not contain any arc.
+        unsigned last_arc;
+        float last_fraction;
+};
+
+struct SnapResponse
+{
+        // snapped is false if there is no road network point within the radius,
+        // in which case the other fields are not set.
+        bool snapped;
+        Point point;
+        // distance is the straight-line distance in meters from the query
+        // point to point.
+        float distance;
+        // way_id, name and highway describe the OSM way closest to the query
+        // point among the ways at point, or the way of the arc the query point
+        // was projected onto. name and highway are empty if the way has no
+        // such tags.
+        long long way_id;
+        const char *name;
+        const char *highway;
+};
+
+struct DistancesResponse
+{
+        std::vector<unsigned> distances;
+        // statuses holds a query_status per target.
+        std::vector<int> statuses;
+        std::vector<unsigned> geo_distances;
+        std::vector<unsigned> travel_times;
+};
+
+// ReachResponse describes the part of the road network reachable from a
+// point.
+struct ReachResponse
+{
+        // points are positions on the reachable arcs, at most a given spacing
+        // apart along every arc, and costs holds the cost of reaching each of
+        // them.
+        std::vector<Point> points;
+        std::vector<unsigned> costs;
+};
+
+// MatchResponse describes the roads a trace of GPS positions was matched to.
+struct MatchResponse
+{
+        // points holds the matched position of every position of the trace,
+        // matched whether it was matched to a road at all, and confidences
+        // the probability that it was measured within sigma meters of the
+        // matched position given the whole trace.
+        std::vector<Point> points;
+        std::vector<int> matched;
+        std::vector<float> confidences;
+        // path holds the waypoints of the matched route, and distance its
+        // length in meters.
+        std::vector<Point> path;
+        unsigned distance;
 };
 
 enum transport_mode
@@ -33,12 +148,23 @@ struct Profile
         const char *name;
         bool prevent_left_turns;
         bool prevent_u_turns;
-        bool travel_time;
 };
 
 namespace GoRoutingKit
 {
         extern const unsigned max_distance;
+        extern const unsigned invalid_arc;
+
+        // install_crash_handlers installs handlers for fatal signals (SIGSEGV,
+        // SIGBUS, SIGILL, SIGABRT, SIGFPE and SIGSYS) that write a stack trace
+        // to the file descriptor fd before the process terminates. This affects
+        // the whole process, but faults in the Go code between go_text_start
+        // and go_text_end are passed on to the handlers of the Go runtime. If
+        // forwarded_fd is not -1, fd is closed after writing the stack trace,
+        // and the process terminates once forwarded_fd is hung up, which
+        // signals that the reader of fd forwarded the stack trace, or after a
+        // second.
+        void install_crash_handlers(int fd, int forwarded_fd, unsigned long long go_text_start, unsigned long long go_text_end);
 
         struct RoutingGraph
         {
@@ -50,6 +176,19 @@ namespace GoRoutingKit
                 std::vector<float> longitude;
                 std::vector<unsigned> forbidden_turn_from_arc;
                 std::vector<unsigned> forbidden_turn_to_arc;
+                // way holds the routing way of each arc, and way_speed the
+                // speed in km/h of each routing way.
+                std::vector<unsigned> way;
+                std::vector<unsigned> way_speed;
+                // The nodes modelling the shape of arc a between its tail and
+                // head are encoded in the bytes at positions
+                // first_modelling_byte[a] to first_modelling_byte[a+1]-1 of
+                // modelling_bytes: the latitude and longitude of every node,
+                // starting from the tail, are stored as their differences to
+                // the previous node in units of 1e-7 degrees, as zigzag
+                // varints. They are only loaded if requested.
+                std::vector<unsigned long long> first_modelling_byte;
+                std::vector<unsigned char> modelling_bytes;
 
                 unsigned node_count() const
                 {
@@ -62,20 +201,228 @@ namespace GoRoutingKit
                 }
         };
 
+        // SnappedPoint describes where a query point was snapped to the routing
+        // graph. It is defined in Client.cpp.
+        struct SnappedPoint;
+
+        // RoadNetwork holds the routing graph loaded from a map file. It can be
+        // shared by several clients, e.g. one per metric.
+        class RoadNetwork
+        {
+                friend class Client;
+                RoutingGraph graph;
+                RoutingKit::GeoPositionToNode map;
+                // tail holds the tail of each arc and first_in and in_arc list
+                // the arcs entering each node.
+                std::vector<unsigned> tail;
+                std::vector<unsigned> first_in;
+                std::vector<unsigned> in_arc;
+                // OSM data of the routing ways
+                std::vector<long long> way_osm_id;
+                std::vector<std::string> way_name;
+                std::vector<std::string> way_ref;
+                std::vector<std::string> way_highway;
+                std::vector<bool> way_roundabout;
+                std::string error;
+                // arc_grid lists the arcs whose shapes have bounding boxes
+                // overlapping a grid cell. It is built when it is first
+                // needed.
+                std::unordered_map<long long, std::vector<unsigned>> arc_grid;
+                std::once_flag arc_grid_built;
+
+                void polyline(unsigned arc, std::vector<Point> &points) const;
+                unsigned nearest_arc(unsigned node, float lat, float lon) const;
+                void build_arc_grid();
+                std::vector<unsigned> arcs_near(float lat, float lon, float radius) const;
+                unsigned nearest_arc_within_radius(float lat, float lon, float radius, double &fraction) const;
+
+        public:
+                // If geometry is set, the modelling nodes of the arcs are loaded,
+                // so that points are projected onto the shape of the roads and
+                // the waypoints of routes follow it.
+                RoadNetwork(char *pbf_file, Profile customProfile, bool geometry);
+                // load_error returns a description of the error that occurred while
+                // loading the network, or an empty string if there was none.
+                const char *load_error() const;
+        };
+
+        // TargetBuckets holds the targets of a many-to-many search together
+        // with the buckets filled by the backward searches from them, so that
+        // the rows of several sets of sources can be computed without
+        // searching from the targets again. It is created by
+        // Client::target_buckets and must only be used with that client.
+        class TargetBuckets
+        {
+                friend class Client;
+                struct Data;
+                std::shared_ptr<const Data> data;
+
+        public:
+                unsigned target_count() const;
+        };
+
         class Client
         {
-                Point point(int i);
+                Point point(int i) const;
+                Point position(unsigned arc, double fraction) const;
+                SnappedPoint snap_point(float radius, float lon, float lat, float bearing = 0, float tolerance = 0) const;
+                SnappedPoint continue_from(unsigned arc, float fraction) const;
+                void add_offsets(SnappedPoint &snapped) const;
+                QueryResponse route(int i, const SnappedPoint &from, const SnappedPoint &to, bool include_waypoints, bool include_segments, std::vector<unsigned> *path_arcs = nullptr);
+                std::vector<unsigned> via_candidates(Point from, Point to, double length, std::vector<long long> &cells) const;
+                void sample_arc(unsigned arc, double from, double to, unsigned from_cost, unsigned to_cost, float spacing,
+                                ReachResponse &response) const;
+                void append_geometry(unsigned arc, double from, double to, std::vector<Point> &waypoints) const;
+                Segment segment(unsigned arc, double from, double to) const;
+                std::vector<unsigned> distances_to_targets(int i, float radius, Point source, std::vector<Point> targets, DistancesResponse *response, bool include_metrics,
+                                                           float source_bearing = 0, float source_tolerance = 0,
+                                                           std::vector<float> target_bearings = {}, std::vector<float> target_tolerances = {});
                 RoutingKit::ContractionHierarchy ch;
-                RoutingKit::GeoPositionToNode map;
+                RoutingKit::ContractionHierarchyExtraWeight<unsigned> geo_distance;
+                RoutingKit::ContractionHierarchyExtraWeight<unsigned> travel_time;
                 std::vector<RoutingKit::ContractionHierarchyQuery> queries;
-                RoutingGraph graph;
+                // Search holds the state of the many-to-many, one-to-all and
+                // reachability searches run with a query slot. It is defined
+                // in Client.cpp. searches holds the state of every slot, which
+                // is created when the slot is first used for such a search.
+                struct Search;
+                std::vector<std::shared_ptr<Search>> searches;
+                Search &search(int i);
+                RoadNetwork *network;
+                // weight is the metric minimized by the contraction hierarchy.
+                const std::vector<unsigned> *weight;
+                bool snap_to_arcs;
+                std::string error;
 
         public:
-                QueryResponse query(int i, float radius, float from_longitude, float from_latitude,
-                                    float to_longitude, float to_latitude, bool include_waypoints);
-                std::vector<unsigned> distances(int i, float radius, Point source, std::vector<Point> targets);
+                // query and distances take a bearing and a tolerance per
+                // point; all other methods snap points without headings. A
+                // point given with a positive tolerance has a heading: it is
+                // projected onto the nearest arc within the radius whose
+                // direction differs from the bearing, in degrees clockwise
+                // from north, by at most tolerance degrees. If there is no
+                // such arc, the point is snapped as usual.
+                QueryResponse query(int i, float radius, float from_longitude, float from_latitude, float from_bearing,
+                                    float from_tolerance, float to_longitude, float to_latitude, float to_bearing,
+                                    float to_tolerance, bool include_waypoints, bool include_segments);
+                // continue_query is like query, but starts at the given position
+                // on the arc, which is where a previous query ended, and does
+                // not turn back along the arc.
+                QueryResponse continue_query(int i, float radius, unsigned arc, float fraction,
+                                             float to_longitude, float to_latitude, bool include_waypoints,
+                                             bool include_segments);
+                // alternatives returns the shortest path followed by up to k - 1
+                // alternative paths, ordered by cost. An alternative costs at
+                // most max_stretch times as much as the shortest path, and at
+                // most max_overlap of its length is shared with any path
+                // returned before it.
+                std::vector<QueryResponse> alternatives(int i, float radius, float from_longitude, float from_latitude,
+                                                        float to_longitude, float to_latitude, unsigned k, float max_stretch,
+                                                        float max_overlap, bool include_waypoints, bool include_segments);
+                // many_to_many computes the distances from the sources to the
+                // targets with a bucket-based many-to-many search on the
+                // contraction hierarchy, using one thread for each of the
+                // query slots, of which there must be at least one. The cells
+                // are stored row by row in distances and, if they are not
+                // null, their statuses in statuses and the length and travel
+                // time of the paths in geo_distances and travel_times. Each
+                // buffer must hold one element per cell.
+                // Once cancelled, if it is not null, points to a value other
+                // than zero, no more rows are computed. It returns the number
+                // of rows computed, which are the first ones.
+                unsigned many_to_many(std::vector<int> slots, float radius, std::vector<Point> sources, std::vector<Point> targets,
+                                      unsigned *distances, int *statuses, unsigned *geo_distances, unsigned *travel_times,
+                                      const int *cancelled);
+                // target_buckets runs the backward searches of many_to_many
+                // from the targets, and many_to_many_rows runs the forward
+                // searches from the sources and stores their rows like
+                // many_to_many does. The backward searches only reach nodes
+                // at a distance of at most bound from the targets.
+                TargetBuckets target_buckets(std::vector<int> slots, float radius, std::vector<Point> targets, unsigned bound);
+                unsigned many_to_many_rows(std::vector<int> slots, const TargetBuckets &buckets, float radius, std::vector<Point> sources,
+                                           unsigned *distances, int *statuses, unsigned *geo_distances, unsigned *travel_times,
+                                           const int *cancelled);
+                // sparse_many_to_many is like many_to_many_rows, but only
+                // returns the cells whose distance is at most bound, which
+                // should be the bound of the buckets. Every cell is given by
+                // three consecutive elements: the index of the source, the
+                // index of the target and the distance. The cells are ordered
+                // by source and target, and only the cells of the rows
+                // computed before the search was cancelled are returned.
+                std::vector<unsigned> sparse_many_to_many(std::vector<int> slots, const TargetBuckets &buckets, float radius,
+                                                          std::vector<Point> sources, unsigned bound, const int *cancelled);
+                // nearest_targets returns up to k of the targets of the buckets
+                // that can be reached from the source at a cost of at most
+                // bound, ordered by cost and, for equal costs, by index. Every
+                // target is given by two consecutive elements: its index and
+                // its cost. The forward search stops once no target it has
+                // not reached yet can be among the k nearest.
+                std::vector<unsigned> nearest_targets(int i, const TargetBuckets &buckets, float radius, Point source,
+                                                      unsigned k, unsigned bound);
+                // node_count returns the number of nodes of the routing graph,
+                // and node_positions stores the longitude and latitude of every
+                // node in longitudes and latitudes.
+                unsigned node_count() const;
+                void node_positions(float *longitudes, float *latitudes) const;
+                // one_to_all computes the distances from the source to all
+                // nodes of the routing graph with a PHAST sweep: an upward
+                // search from the source followed by a scan of all downward
+                // arcs of the contraction hierarchy from the highest to the
+                // lowest ranked node, using the search state of query slot i.
+                // It stores the distances in costs, indexed by node, and
+                // returns false if the source cannot be snapped. Once
+                // cancelled, if it is not null, points to a value other than
+                // zero, the sweep stops and costs are left incomplete.
+                bool one_to_all(int i, float radius, float longitude, float latitude, unsigned *costs, const int *cancelled);
+                // sample_costs computes the distances from the source to the
+                // points from the costs of one_to_all for the same source, and
+                // stores them in sampled.
+                void sample_costs(float radius, float longitude, float latitude, const unsigned *costs, std::vector<Point> points,
+                                  unsigned *sampled) const;
+                // reachable runs Dijkstra's algorithm on the routing graph from
+                // the point up to the limit, and samples the reached arcs, up
+                // to where they are reached within the limit, at most spacing
+                // meters apart. If reverse is set, the search runs on the
+                // reversed graph, so it finds the positions from which the
+                // point can be reached within the limit. The search uses the
+                // state of query slot i, and stops once cancelled, if it is
+                // not null, points to a value other than zero.
+                ReachResponse reachable(int i, float radius, float longitude, float latitude, unsigned limit, float spacing, bool reverse,
+                                        const int *cancelled);
+                // map_match matches the trace to the roads with a hidden
+                // Markov model. The candidates of a position are the positions
+                // on the max_candidates nearest arcs within radius meters,
+                // whose distance to the position is normally distributed with
+                // standard deviation sigma. The difference between the length
+                // of the path between candidates and the straight distance
+                // between the positions is exponentially distributed with mean
+                // beta, and paths longer than what can be driven at max_speed
+                // meters per second in the time between the positions, given
+                // in seconds, are impossible.
+                MatchResponse map_match(int i, float radius, std::vector<Point> trace, std::vector<float> seconds, float sigma,
+                                        float beta, float max_speed, unsigned max_candidates);
+                // distances computes the distances from the source to the
+                // targets. The headings of the targets are ignored unless
+                // target_bearings and target_tolerances hold one element per
+                // target.
+                std::vector<unsigned> distances(int i, float radius, Point source, float source_bearing, float source_tolerance,
+                                                std::vector<Point> targets, std::vector<float> target_bearings,
+                                                std::vector<float> target_tolerances);
+                // detailed_distances is like distances, but also returns the status
+                // of the queries and, if include_metrics is set, the length and
+                // travel time of the paths to the targets.
+                DistancesResponse detailed_distances(int i, float radius, Point source, std::vector<Point> targets, bool include_metrics);
                 Point *nearest(int i, float radius, float lon, float lat);
-                Client(int conc, char *pbf_file, char *ch_file, Profile customProfile);
+                SnapResponse snap(int i, float radius, float lon, float lat);
+                // The network must outlive the client. The contraction hierarchy
+                // minimizes the travel time if travel_time is set and the distance
+                // otherwise. If snap_to_arcs is set, query points are projected
+                // onto the nearest arc instead of being snapped to the nearest
+                // node.
+                Client(int conc, RoadNetwork *network, char *ch_file, bool travel_time, bool snap_to_arcs);
+                // load_error returns a description of the error that occurred while
+                // constructing the client, or an empty string if there was none.
+                const char *load_error() const;
         };
 }
 
@@ -17,6 +17,8 @@ namespace std {
   %template(IntVector) vector<int>;
   %template(FloatVector) vector<float>;
   %template(PointVector) vector<Point>;
+  %template(SegmentVector) vector<Segment>;
+  %template(QueryResponseVector) vector<QueryResponse>;
   %template(UnsignedVector) vector<unsigned>;
   %template(LongIntVector) vector<long int>;
   %template(IntIntMap) map<unsigned long long, unsigned int>;